@@ -18,32 +18,15 @@ func FunctionSelector(signature string) string {
 }
 
 func ABIEncodeAddressParam(addr string) (string, error) {
-	addr = strings.TrimSpace(addr)
-	var hexAddr string
-	var err error
-
-	if strings.HasPrefix(addr, "T") {
-		hexAddr, err = TronBase58ToHex(addr)
-		if err != nil {
-			return "", err
-		}
-	} else {
-		hexAddr = strings.TrimPrefix(strings.ToLower(strings.TrimPrefix(addr, "0x")), "0x")
-	}
-
-	hexAddr = strings.TrimPrefix(hexAddr, "0x")
-	hexAddr = strings.ToLower(hexAddr)
-
-	b, err := hex.DecodeString(hexAddr)
+	a, err := ParseAddress(addr)
 	if err != nil {
 		return "", err
 	}
-	if len(b) != 21 {
-		return "", fmt.Errorf("tron address must be 21 bytes (41 + 20), got %d", len(b))
-	}
+	return ABIEncodeAddress(a), nil
+}
 
-	evm20 := b[1:]
-	return leftPad32Hex(hex.EncodeToString(evm20)), nil
+func ABIEncodeAddress(addr Address) string {
+	return leftPad32Hex(hex.EncodeToString(addr[1:]))
 }
 
 func ABIEncodeUint256Param(n *big.Int) (string, error) {
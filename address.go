@@ -5,27 +5,172 @@ import (
 	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/sha256"
+	"database/sql/driver"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"golang.org/x/crypto/sha3"
 )
 
-var b58Alphabet = []byte("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
-var b58Indexes [256]int
+const (
+	AddressLength = 21
+	AddressPrefix = 0x41
+)
+
+type Address [AddressLength]byte
 
-func init() {
-	for i := range b58Indexes {
-		b58Indexes[i] = -1
+// ParseAddress accepts base58 (T...), 41-prefixed hex and 20-byte EVM hex
+// with or without 0x.
+func ParseAddress(s string) (Address, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Address{}, errors.New("empty address")
 	}
-	for i, c := range b58Alphabet {
-		b58Indexes[c] = i
+
+	if strings.HasPrefix(s, "T") {
+		if err := ValidateBase58Address(s); err != nil {
+			return Address{}, err
+		}
+		raw, err := base58Decode(s)
+		if err != nil {
+			return Address{}, err
+		}
+		var a Address
+		copy(a[:], raw[:AddressLength])
+		return a, nil
+	}
+
+	h := strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return Address{}, fmt.Errorf("invalid hex address: %w", err)
+	}
+	return AddressFromBytes(b)
+}
+
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// AddressFromBytes accepts the 21-byte TRON form or a 20-byte EVM address.
+func AddressFromBytes(b []byte) (Address, error) {
+	var a Address
+	switch {
+	case len(b) == AddressLength && b[0] == AddressPrefix:
+		copy(a[:], b)
+	case len(b) == common.AddressLength:
+		a[0] = AddressPrefix
+		copy(a[1:], b)
+	case len(b) == AddressLength:
+		return Address{}, errors.New("invalid tron network prefix")
+	default:
+		return Address{}, fmt.Errorf("invalid address length %d", len(b))
+	}
+	return a, nil
+}
+
+func AddressFromEVM(evm common.Address) Address {
+	var a Address
+	a[0] = AddressPrefix
+	copy(a[1:], evm[:])
+	return a
+}
+
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+func (a Address) Bytes() []byte {
+	return a[:]
+}
+
+func (a Address) Base58() string {
+	return base58Encode(append(a.Bytes(), checksum4(a[:])...))
+}
+
+func (a Address) String() string {
+	return a.Base58()
+}
+
+func (a Address) Hex() string {
+	return hex.EncodeToString(a[:])
+}
+
+func (a Address) EVM() common.Address {
+	return common.BytesToAddress(a[1:])
+}
+
+func (a Address) EVMHex() string {
+	return a.EVM().Hex()
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	if a.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(a.Base58()), nil
+}
+
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = Address{}
+		return nil
+	}
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+func (a *Address) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Address{}
+		return nil
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		if len(v) == AddressLength || len(v) == common.AddressLength {
+			parsed, err := AddressFromBytes(v)
+			if err == nil {
+				*a = parsed
+				return nil
+			}
+		}
+		return a.UnmarshalText(v)
 	}
+	return fmt.Errorf("cannot scan %T into Address", src)
 }
 
+func (a Address) Value() (driver.Value, error) {
+	if a.IsZero() {
+		return nil, nil
+	}
+	return a.Base58(), nil
+}
+
+var b58Alphabet = []byte("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+var b58Indexes = func() [256]int {
+	var idx [256]int
+	for i := range idx {
+		idx[i] = -1
+	}
+	for i, c := range b58Alphabet {
+		idx[c] = i
+	}
+	return idx
+}()
+
 func TronBase58ToHex(b58 string) (string, error) {
 	raw, err := base58Decode(strings.TrimSpace(b58))
 	if err != nil {
@@ -193,6 +338,14 @@ func PrivateKeyHexToAddressBase58(privateKeyHex string) (string, error) {
 	return base58Encode(full), nil
 }
 
+func PrivateKeyHexToAddress(privateKeyHex string) (Address, error) {
+	priv, err := parsePrivateKeyHex(privateKeyHex)
+	if err != nil {
+		return Address{}, err
+	}
+	return publicKeyToAddress(&priv.PublicKey), nil
+}
+
 func checksum(input []byte) []byte {
 	h1 := sha256.Sum256(input)
 	h2 := sha256.Sum256(h1[:])
@@ -245,3 +398,22 @@ func ValidateBase58Address(addr string) error {
 
 	return nil
 }
+
+func publicKeyToAddress(pub *ecdsa.PublicKey) Address {
+	return AddressFromEVM(crypto.PubkeyToAddress(*pub))
+}
+
+func parsePrivateKeyHex(privateKeyHex string) (*ecdsa.PrivateKey, error) {
+	privateKeyHex = strings.TrimSpace(privateKeyHex)
+	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
+	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0X")
+	if privateKeyHex == "" {
+		return nil, errors.New("empty private key")
+	}
+
+	priv, err := crypto.HexToECDSA(privateKeyHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return priv, nil
+}
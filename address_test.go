@@ -0,0 +1,64 @@
+package tron_test
+
+import (
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+func TestParseAddress(t *testing.T) {
+	const (
+		usdt    = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+		usdtHex = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+		zero    = "T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb"
+	)
+
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: usdt, want: usdt},
+		{in: "  " + usdt + "\n", want: usdt},
+		{in: usdtHex, want: usdt},
+		{in: "0x" + usdtHex, want: usdt},
+		{in: "0xa614f803b6fd780986a42c78ec9c7f77e6ded13c", want: usdt},
+		{in: "0xA614F803B6FD780986A42C78EC9C7F77E6DED13C", want: usdt},
+		{in: "410000000000000000000000000000000000000000", want: zero},
+		{in: "", wantErr: true},
+		{in: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", wantErr: true}, // bad checksum
+		{in: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6", wantErr: true},  // truncated
+		{in: "0xa614f803b6fd780986a42c78ec9c7f77e6ded1", wantErr: true},
+		{in: "42a614f803b6fd780986a42c78ec9c7f77e6ded13c", wantErr: true}, // wrong prefix
+		{in: "0xzz14f803b6fd780986a42c78ec9c7f77e6ded13c", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tron.ParseAddress(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAddress(%q) = %s, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAddress(%q): %v", tt.in, err)
+			continue
+		}
+		if got.String() != tt.want {
+			t.Errorf("ParseAddress(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAddressForms(t *testing.T) {
+	a := tron.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	if got, want := a.Hex(), "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"; got != want {
+		t.Errorf("Hex = %s, want %s", got, want)
+	}
+	if got := tron.AddressFromEVM(a.EVM()); got != a {
+		t.Errorf("AddressFromEVM(EVM()) = %s, want %s", got, a)
+	}
+	if a.IsZero() || !(tron.Address{}).IsZero() {
+		t.Error("IsZero must only hold for the unset address")
+	}
+}
@@ -0,0 +1,314 @@
+package tron
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Amount is an exact token amount: an integer number of base units (sun for
+// TRX) together with the token's decimals.
+type Amount struct {
+	value    *big.Int
+	decimals uint8
+}
+
+func NewAmount(baseUnits *big.Int, decimals uint8) Amount {
+	v := new(big.Int)
+	if baseUnits != nil {
+		v.Set(baseUnits)
+	}
+	return Amount{value: v, decimals: decimals}
+}
+
+func NewTRXAmount(sun *big.Int) Amount {
+	return NewAmount(sun, TrxDecimals)
+}
+
+// ParseAmount parses a decimal string such as "12.5" into base units. It
+// fails rather than rounds when s has more fractional digits than decimals.
+func ParseAmount(s string, decimals uint8) (Amount, error) {
+	v, err := parseDecimal(s, decimals, false)
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{value: v, decimals: decimals}, nil
+}
+
+func ParseTRX(s string) (Amount, error) {
+	return ParseAmount(s, TrxDecimals)
+}
+
+func parseDecimal(s string, decimals uint8, truncate bool) (*big.Int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, errors.New("empty amount")
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	for _, part := range []string{intPart, fracPart} {
+		for _, ch := range part {
+			if ch < '0' || ch > '9' {
+				return nil, fmt.Errorf("invalid amount %q", s)
+			}
+		}
+	}
+
+	if len(fracPart) > int(decimals) {
+		if !truncate && strings.TrimRight(fracPart[decimals:], "0") != "" {
+			return nil, fmt.Errorf("amount %q has more than %d decimals", s, decimals)
+		}
+		fracPart = fracPart[:decimals]
+	}
+	fracPart += strings.Repeat("0", int(decimals)-len(fracPart))
+
+	v, ok := new(big.Int).SetString("0"+intPart+fracPart, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q", s)
+	}
+	if neg {
+		v.Neg(v)
+	}
+	return v, nil
+}
+
+func (a Amount) BaseUnits() *big.Int {
+	if a.value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(a.value)
+}
+
+func (a Amount) Decimals() uint8 {
+	return a.decimals
+}
+
+func (a Amount) Sign() int {
+	if a.value == nil {
+		return 0
+	}
+	return a.value.Sign()
+}
+
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+// String formats the amount with exactly Decimals() fractional digits.
+func (a Amount) String() string {
+	v := a.BaseUnits()
+	neg := v.Sign() < 0
+	digits := v.Abs(v).String()
+
+	if a.decimals > 0 {
+		if len(digits) <= int(a.decimals) {
+			digits = strings.Repeat("0", int(a.decimals)-len(digits)+1) + digits
+		}
+		split := len(digits) - int(a.decimals)
+		digits = digits[:split] + "." + digits[split:]
+	}
+	if neg {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Rescale converts the amount to other decimals, failing if precision would
+// be lost.
+func (a Amount) Rescale(decimals uint8) (Amount, error) {
+	v := a.BaseUnits()
+	switch {
+	case decimals > a.decimals:
+		v.Mul(v, pow10(decimals-a.decimals))
+	case decimals < a.decimals:
+		q, r := new(big.Int).QuoRem(v, pow10(a.decimals-decimals), new(big.Int))
+		if r.Sign() != 0 {
+			return Amount{}, fmt.Errorf("rescaling %s to %d decimals loses precision", a, decimals)
+		}
+		v = q
+	}
+	return Amount{value: v, decimals: decimals}, nil
+}
+
+func (a Amount) Add(b Amount) (Amount, error) {
+	if a.decimals != b.decimals {
+		return Amount{}, fmt.Errorf("decimals mismatch: %d and %d", a.decimals, b.decimals)
+	}
+	return Amount{value: new(big.Int).Add(a.BaseUnits(), b.BaseUnits()), decimals: a.decimals}, nil
+}
+
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if a.decimals != b.decimals {
+		return Amount{}, fmt.Errorf("decimals mismatch: %d and %d", a.decimals, b.decimals)
+	}
+	return Amount{value: new(big.Int).Sub(a.BaseUnits(), b.BaseUnits()), decimals: a.decimals}, nil
+}
+
+// MulInt treats a nil n as zero, like NewAmount.
+func (a Amount) MulInt(n *big.Int) Amount {
+	if n == nil {
+		return Amount{value: new(big.Int), decimals: a.decimals}
+	}
+	return Amount{value: new(big.Int).Mul(a.BaseUnits(), n), decimals: a.decimals}
+}
+
+func (a Amount) Neg() Amount {
+	return Amount{value: new(big.Int).Neg(a.BaseUnits()), decimals: a.decimals}
+}
+
+// Cmp compares the amounts by value, so 1.5 with 1 decimal equals 1.50 with 2.
+func (a Amount) Cmp(b Amount) int {
+	x, y := a.BaseUnits(), b.BaseUnits()
+	switch {
+	case a.decimals < b.decimals:
+		x.Mul(x, pow10(b.decimals-a.decimals))
+	case a.decimals > b.decimals:
+		y.Mul(y, pow10(a.decimals-b.decimals))
+	}
+	return x.Cmp(y)
+}
+
+func (a Amount) Equal(b Amount) bool {
+	return a.Cmp(b) == 0
+}
+
+func (a Amount) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText takes the decimals from the number of fractional digits, which
+// round-trips String. Exponent form such as "1e-6" is accepted as well.
+func (a *Amount) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if strings.ContainsAny(s, "eE") {
+		var err error
+		if s, err = expandExponent(s); err != nil {
+			return err
+		}
+	}
+	_, frac, _ := strings.Cut(s, ".")
+	if len(frac) > 255 {
+		return fmt.Errorf("amount %q has too many decimals", s)
+	}
+
+	parsed, err := ParseAmount(s, uint8(len(frac)))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// maxAmountExponent bounds exponent form so a tiny input cannot expand into
+// a huge number.
+const maxAmountExponent = 1000
+
+// expandExponent rewrites a number in exponent form, e.g. "1.5e-6", as a plain
+// decimal ("0.0000015") without going through floating point.
+func expandExponent(s string) (string, error) {
+	mant, expStr, _ := strings.Cut(strings.ToLower(s), "e")
+	exp, err := strconv.Atoi(expStr)
+	if err != nil || exp > maxAmountExponent || exp < -maxAmountExponent {
+		return "", fmt.Errorf("invalid amount %q", s)
+	}
+
+	sign := ""
+	if mant != "" && (mant[0] == '-' || mant[0] == '+') {
+		sign, mant = mant[:1], mant[1:]
+	}
+	intPart, fracPart, _ := strings.Cut(mant, ".")
+	if intPart == "" && fracPart == "" {
+		return "", fmt.Errorf("invalid amount %q", s)
+	}
+
+	digits := intPart + fracPart
+	point := len(intPart) + exp
+	switch {
+	case point <= 0:
+		return sign + "0." + strings.Repeat("0", -point) + digits, nil
+	case point >= len(digits):
+		return sign + digits + strings.Repeat("0", point-len(digits)), nil
+	}
+	return sign + digits[:point] + "." + digits[point:], nil
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a *Amount) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		var n json.Number
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("amount must be a decimal string or number: %w", err)
+		}
+		s = n.String()
+	}
+	return a.UnmarshalText([]byte(s))
+}
+
+// Scan reads SQL NULL as the zero amount. Float columns are read through
+// their shortest decimal form, like ToBlockchainAmount.
+func (a *Amount) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = Amount{}
+		return nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("cannot scan %v into Amount", v)
+		}
+		return a.UnmarshalText([]byte(strconv.FormatFloat(v, 'g', -1, 64)))
+	case string:
+		return a.UnmarshalText([]byte(v))
+	case []byte:
+		return a.UnmarshalText(v)
+	case int64:
+		*a = NewAmount(big.NewInt(v), 0)
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into Amount", src)
+}
+
+func (a Amount) Value() (driver.Value, error) {
+	return a.String(), nil
+}
+
+func pow10(n uint8) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func (t *TRC20) Amount(ctx context.Context, baseUnits *big.Int) (Amount, error) {
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return Amount{}, err
+	}
+	return NewAmount(baseUnits, decimals), nil
+}
+
+func (t *TRC20) ParseAmount(ctx context.Context, s string) (Amount, error) {
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return Amount{}, err
+	}
+	return ParseAmount(s, decimals)
+}
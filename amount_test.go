@@ -0,0 +1,186 @@
+package tron_test
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in       string
+		decimals uint8
+		want     string // base units
+		wantErr  bool
+	}{
+		{in: "1", decimals: 6, want: "1000000"},
+		{in: "12.5", decimals: 6, want: "12500000"},
+		{in: "0.000001", decimals: 6, want: "1"},
+		{in: ".5", decimals: 1, want: "5"},
+		{in: "-1.25", decimals: 2, want: "-125"},
+		{in: "1.500", decimals: 1, want: "15"},
+		{in: "123456789012345678901234567890", decimals: 18, want: "123456789012345678901234567890000000000000000000"},
+		{in: "0.0000001", decimals: 6, wantErr: true},
+		{in: "", decimals: 6, wantErr: true},
+		{in: "1.2.3", decimals: 6, wantErr: true},
+		{in: "abc", decimals: 6, wantErr: true},
+		{in: "1e6", decimals: 6, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tron.ParseAmount(tt.in, tt.decimals)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q, %d) = %s, want error", tt.in, tt.decimals, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseAmount(%q, %d): %v", tt.in, tt.decimals, err)
+			continue
+		}
+		if got.BaseUnits().String() != tt.want || got.Decimals() != tt.decimals {
+			t.Errorf("ParseAmount(%q, %d) = %s units with %d decimals, want %s", tt.in, tt.decimals, got.BaseUnits(), got.Decimals(), tt.want)
+		}
+	}
+}
+
+func TestAmountString(t *testing.T) {
+	tests := []struct {
+		units    int64
+		decimals uint8
+		want     string
+	}{
+		{units: 1_500_000, decimals: 6, want: "1.500000"},
+		{units: 1, decimals: 6, want: "0.000001"},
+		{units: -5, decimals: 2, want: "-0.05"},
+		{units: 42, decimals: 0, want: "42"},
+	}
+	for _, tt := range tests {
+		if got := tron.NewAmount(big.NewInt(tt.units), tt.decimals).String(); got != tt.want {
+			t.Errorf("NewAmount(%d, %d) = %s, want %s", tt.units, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestAmountRescale(t *testing.T) {
+	a, err := tron.ParseAmount("1.5", 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	up, err := a.Rescale(18)
+	if err != nil {
+		t.Fatalf("rescale up: %v", err)
+	}
+	if got, want := up.BaseUnits().String(), "1500000000000000000"; got != want {
+		t.Fatalf("rescale to 18 = %s, want %s", got, want)
+	}
+
+	down, err := a.Rescale(1)
+	if err != nil {
+		t.Fatalf("rescale down: %v", err)
+	}
+	if got := down.BaseUnits().Int64(); got != 15 {
+		t.Fatalf("rescale to 1 = %d, want 15", got)
+	}
+	if !down.Equal(a) {
+		t.Fatalf("%s and %s compare unequal", down, a)
+	}
+
+	if _, err := a.Rescale(0); err == nil {
+		t.Fatal("rescaling 1.5 to 0 decimals succeeded, want precision error")
+	}
+}
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     string
+		decimals uint8
+		wantErr  bool
+	}{
+		{in: `"1.25"`, want: "125", decimals: 2},
+		{in: `1.25`, want: "125", decimals: 2},
+		{in: `1e-6`, want: "1", decimals: 6},
+		{in: `1.5E+3`, want: "1500", decimals: 0},
+		{in: `-2.5e-1`, want: "-25", decimals: 2},
+		{in: `"1e-6"`, want: "1", decimals: 6},
+		{in: `1e100000`, wantErr: true},
+		{in: `true`, wantErr: true},
+	}
+	for _, tt := range tests {
+		var a tron.Amount
+		err := json.Unmarshal([]byte(tt.in), &a)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("unmarshal %s = %s, want error", tt.in, a)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unmarshal %s: %v", tt.in, err)
+			continue
+		}
+		if a.BaseUnits().String() != tt.want || a.Decimals() != tt.decimals {
+			t.Errorf("unmarshal %s = %s units with %d decimals, want %s with %d", tt.in, a.BaseUnits(), a.Decimals(), tt.want, tt.decimals)
+		}
+	}
+}
+
+func TestToBlockchainAmount(t *testing.T) {
+	got, err := tron.ToBlockchainAmountChecked(big.NewFloat(0.1), 6)
+	if err != nil {
+		t.Fatalf("convert 0.1: %v", err)
+	}
+	if got.Int64() != 100_000 {
+		t.Fatalf("convert 0.1 = %s, want 100000", got)
+	}
+	if got := tron.TRXToSun(big.NewFloat(0.1)); got.Int64() != 100_000 {
+		t.Fatalf("TRXToSun(0.1) = %s, want 100000", got)
+	}
+
+	if _, err := tron.ToBlockchainAmountChecked(big.NewFloat(math.Inf(1)), 6); err == nil {
+		t.Fatal("converting +Inf succeeded, want error")
+	}
+	if got := tron.ToBlockchainAmount(big.NewFloat(math.Inf(1)), 6); got != nil {
+		t.Fatalf("ToBlockchainAmount(+Inf) = %s, want nil", got)
+	}
+}
+
+func TestAmountScan(t *testing.T) {
+	tests := []struct {
+		src  any
+		want string
+	}{
+		{nil, "0"},
+		{"12.50", "12.50"},
+		{[]byte("7"), "7"},
+		{int64(42), "42"},
+		{float64(0.1), "0.1"},
+		{float64(1.5e-6), "0.0000015"},
+	}
+	for _, tt := range tests {
+		a := tron.NewAmount(big.NewInt(99), 0)
+		if err := a.Scan(tt.src); err != nil {
+			t.Fatalf("Scan(%v): %v", tt.src, err)
+		}
+		if got := a.String(); got != tt.want {
+			t.Fatalf("Scan(%v) = %s, want %s", tt.src, got, tt.want)
+		}
+	}
+
+	var a tron.Amount
+	if err := a.Scan(math.NaN()); err == nil {
+		t.Fatal("scanning NaN succeeded, want error")
+	}
+}
+
+func TestAmountMulIntNil(t *testing.T) {
+	got := tron.NewTRXAmount(big.NewInt(5)).MulInt(nil)
+	if got.Sign() != 0 || got.Decimals() != tron.TrxDecimals {
+		t.Fatalf("MulInt(nil) = %s with %d decimals, want 0 with 6", got, got.Decimals())
+	}
+}
@@ -3,6 +3,7 @@ package tron
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"time"
 )
@@ -68,11 +69,11 @@ func (c *Client) GetTransactionInfoByID(ctx context.Context, txID string) (Raw,
 }
 
 type GetAccountReq struct {
-	Address string `json:"address"`
-	Visible bool   `json:"visible,omitempty"`
+	Address Address `json:"address"`
+	Visible bool    `json:"visible,omitempty"`
 }
 
-func (c *Client) GetAccount(ctx context.Context, address string) (Raw, error) {
+func (c *Client) GetAccount(ctx context.Context, address Address) (Raw, error) {
 	var out Raw
 	err := c.Call(ctx, "getaccount", GetAccountReq{
 		Address: address,
@@ -82,13 +83,13 @@ func (c *Client) GetAccount(ctx context.Context, address string) (Raw, error) {
 }
 
 type TriggerConstantContractReq struct {
-	OwnerAddress    string `json:"owner_address"`
-	ContractAddress string `json:"contract_address"`
-	Function        string `json:"function_selector"`
-	Parameter       string `json:"parameter,omitempty"`
-	CallValue       int64  `json:"call_value,omitempty"`
-	FeeLimit        int64  `json:"fee_limit,omitempty"`
-	Visible         bool   `json:"visible,omitempty"`
+	OwnerAddress    Address `json:"owner_address"`
+	ContractAddress Address `json:"contract_address"`
+	Function        string  `json:"function_selector"`
+	Parameter       string  `json:"parameter,omitempty"`
+	CallValue       int64   `json:"call_value,omitempty"`
+	FeeLimit        int64   `json:"fee_limit,omitempty"`
+	Visible         bool    `json:"visible,omitempty"`
 }
 
 func (c *Client) TriggerConstantContract(ctx context.Context, req TriggerConstantContractReq) (Raw, error) {
@@ -98,13 +99,14 @@ func (c *Client) TriggerConstantContract(ctx context.Context, req TriggerConstan
 }
 
 type TriggerSmartContractReq struct {
-	OwnerAddress    string `json:"owner_address"`
-	ContractAddress string `json:"contract_address"`
-	Function        string `json:"function_selector"`
-	Parameter       string `json:"parameter,omitempty"`
-	CallValue       int64  `json:"call_value,omitempty"`
-	FeeLimit        int64  `json:"fee_limit,omitempty"`
-	Visible         bool   `json:"visible,omitempty"`
+	OwnerAddress    Address `json:"owner_address"`
+	ContractAddress Address `json:"contract_address"`
+	Function        string  `json:"function_selector"`
+	Parameter       string  `json:"parameter,omitempty"`
+	CallValue       int64   `json:"call_value,omitempty"`
+	FeeLimit        int64   `json:"fee_limit,omitempty"`
+	PermissionID    int     `json:"Permission_id,omitempty"`
+	Visible         bool    `json:"visible,omitempty"`
 }
 
 func (c *Client) TriggerSmartContract(ctx context.Context, req TriggerSmartContractReq) (Raw, error) {
@@ -136,10 +138,11 @@ func (c *Client) BroadcastTransaction(ctx context.Context, signedTx []byte) (*Br
 }
 
 type CreateTransactionReq struct {
-	OwnerAddress string `json:"owner_address"`
-	ToAddress    string `json:"to_address"`
-	Amount       int64  `json:"amount"`
-	Visible      bool   `json:"visible,omitempty"`
+	OwnerAddress Address `json:"owner_address"`
+	ToAddress    Address `json:"to_address"`
+	Amount       int64   `json:"amount"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
 }
 
 func (c *Client) CreateTransaction(ctx context.Context, req CreateTransactionReq) (Raw, error) {
@@ -148,11 +151,13 @@ func (c *Client) CreateTransaction(ctx context.Context, req CreateTransactionReq
 	return out, err
 }
 
-func (c *Client) BuildTransferTRXTx(ctx context.Context, from string, to string, amount *big.Int) (Raw, error) {
+func (c *Client) BuildTransferTRXTx(ctx context.Context, from Address, to Address, amount *big.Int, opts ...TxOption) (Raw, error) {
+	o := applyTxOptions(opts)
 	return c.CreateTransaction(ctx, CreateTransactionReq{
 		OwnerAddress: from,
 		ToAddress:    to,
 		Amount:       amount.Int64(),
+		PermissionID: o.permissionID,
 		Visible:      c.visible,
 	})
 }
@@ -236,7 +241,12 @@ type getAccountResp struct {
 }
 
 func (c *Client) BalanceAt(ctx context.Context, address string) (*big.Int, error) {
-	raw, err := c.GetAccount(ctx, address)
+	addr, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := c.GetAccount(ctx, addr)
 	if err != nil {
 		return nil, err
 	}
@@ -250,45 +260,64 @@ func (c *Client) BalanceAt(ctx context.Context, address string) (*big.Int, error
 }
 
 func (c *Client) BalanceOf(ctx context.Context, tokenAddress string, address string) (*big.Int, error) {
-	return c.NewTRC20(tokenAddress).BalanceOf(ctx, address)
+	token, err := ParseAddress(tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := ParseAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.NewTRC20(token).BalanceOf(ctx, owner)
 }
 
 func (c *Client) TransferToken(ctx context.Context, tokenAddress string, to string, amount *big.Int, privateKey string) (string, error) {
-	from, err := PrivateKeyHexToAddressBase58(privateKey)
+	from, err := PrivateKeyHexToAddress(privateKey)
 	if err != nil {
 		return "", err
 	}
-
-	trc20 := c.NewTRC20(tokenAddress)
-	tx, err := trc20.BuildTransferTx(ctx, from, to, amount, 100000000)
+	token, err := ParseAddress(tokenAddress)
 	if err != nil {
 		return "", err
 	}
-
-	signedTx, err := SignTransaction(tx, privateKey)
+	toAddr, err := ParseAddress(to)
 	if err != nil {
 		return "", err
 	}
 
-	resp, err := c.BroadcastTransaction(ctx, signedTx)
+	trc20 := c.NewTRC20(token)
+	tx, err := trc20.BuildTransferTx(ctx, from, toAddr, amount, 100000000)
 	if err != nil {
 		return "", err
 	}
 
-	return resp.TxID, nil
+	return c.signAndBroadcast(ctx, tx, privateKey,
+		"kind", "trc20", "token", token, "from", from, "to", toAddr, "amount", amount)
 }
 
 func (c *Client) TransferNative(ctx context.Context, to string, amount *big.Int, privateKey string) (string, error) {
-	from, err := PrivateKeyHexToAddressBase58(privateKey)
+	from, err := PrivateKeyHexToAddress(privateKey)
+	if err != nil {
+		return "", err
+	}
+	toAddr, err := ParseAddress(to)
 	if err != nil {
 		return "", err
 	}
 
-	tx, err := c.BuildTransferTRXTx(ctx, from, to, amount)
+	tx, err := c.BuildTransferTRXTx(ctx, from, toAddr, amount)
 	if err != nil {
 		return "", err
 	}
 
+	return c.signAndBroadcast(ctx, tx, privateKey,
+		"kind", "trx", "from", from, "to", toAddr, "amount", amount)
+}
+
+// signAndBroadcast signs and broadcasts tx and logs the outcome; attrs
+// describe the transfer. The private key is never logged.
+func (c *Client) signAndBroadcast(ctx context.Context, tx []byte, privateKey string, attrs ...any) (string, error) {
 	signedTx, err := SignTransaction(tx, privateKey)
 	if err != nil {
 		return "", err
@@ -299,5 +328,319 @@ func (c *Client) TransferNative(ctx context.Context, to string, amount *big.Int,
 		return "", err
 	}
 
+	if resp.Result {
+		c.logger.InfoContext(ctx, "transaction broadcast", append(attrs, "txid", resp.TxID)...)
+	} else {
+		c.logger.WarnContext(ctx, "transaction rejected",
+			append(attrs, "txid", resp.TxID, "code", resp.Code, "message", resp.Message)...)
+	}
 	return resp.TxID, nil
 }
+
+type AccountIdentifier struct {
+	Address Address `json:"address"`
+}
+
+type BlockIdentifier struct {
+	Hash   string `json:"hash"`
+	Number int64  `json:"number"`
+}
+
+type GetAccountBalanceReq struct {
+	AccountIdentifier AccountIdentifier `json:"account_identifier"`
+	BlockIdentifier   BlockIdentifier   `json:"block_identifier"`
+	Visible           bool              `json:"visible,omitempty"`
+}
+
+type AccountBalance struct {
+	Balance         int64           `json:"balance"`
+	BlockIdentifier BlockIdentifier `json:"block_identifier"`
+}
+
+// GetAccountBalance returns the balance of address at a past block. It needs a
+// node with historical balance lookup enabled.
+func (c *Client) GetAccountBalance(ctx context.Context, address Address, block BlockIdentifier) (*AccountBalance, error) {
+	var out AccountBalance
+	err := c.Call(ctx, "getaccountbalance", GetAccountBalanceReq{
+		AccountIdentifier: AccountIdentifier{Address: address},
+		BlockIdentifier:   block,
+		Visible:           c.visible,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) GetAccountNet(ctx context.Context, address Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getaccountnet", GetAccountReq{Address: address, Visible: c.visible}, &out)
+	return out, err
+}
+
+func (c *Client) GetAccountResource(ctx context.Context, address Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getaccountresource", GetAccountReq{Address: address, Visible: c.visible}, &out)
+	return out, err
+}
+
+type GetAccountByIDReq struct {
+	AccountID string `json:"account_id"`
+	Visible   bool   `json:"visible,omitempty"`
+}
+
+func (c *Client) GetAccountByID(ctx context.Context, accountID string) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getaccountbyid", GetAccountByIDReq{AccountID: accountID, Visible: c.visible}, &out)
+	return out, err
+}
+
+type ValidateAddressReq struct {
+	Address string `json:"address"`
+}
+
+type validateAddressResp struct {
+	Result  bool   `json:"result"`
+	Message string `json:"message"`
+}
+
+// ValidateAddress asks the node whether address is valid. It returns nil for a
+// valid address and the node's message otherwise.
+func (c *Client) ValidateAddress(ctx context.Context, address string) error {
+	var out validateAddressResp
+	if err := c.Call(ctx, "validateaddress", ValidateAddressReq{Address: address}, &out); err != nil {
+		return err
+	}
+	if !out.Result {
+		return fmt.Errorf("invalid address %s: %s", address, out.Message)
+	}
+	return nil
+}
+
+type CreateAccountReq struct {
+	OwnerAddress   Address `json:"owner_address"`
+	AccountAddress Address `json:"account_address"`
+	PermissionID   int     `json:"Permission_id,omitempty"`
+	Visible        bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) CreateAccount(ctx context.Context, req CreateAccountReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "createaccount", req, &out)
+	return out, err
+}
+
+type UpdateAccountReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	AccountName  string  `json:"account_name"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) UpdateAccount(ctx context.Context, req UpdateAccountReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "updateaccount", req, &out)
+	return out, err
+}
+
+type GetBlockReq struct {
+	IDOrNum string `json:"id_or_num,omitempty"`
+	Detail  bool   `json:"detail"`
+}
+
+// GetBlock returns a block by id or number, or the latest block when idOrNum
+// is empty. Without detail only the header is returned.
+func (c *Client) GetBlock(ctx context.Context, idOrNum string, detail bool) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getblock", GetBlockReq{IDOrNum: idOrNum, Detail: detail}, &out)
+	return out, err
+}
+
+type GetBlockByLimitNextReq struct {
+	StartNum int64 `json:"startNum"`
+	EndNum   int64 `json:"endNum"`
+}
+
+// GetBlockByLimitNext returns the blocks in [start, end).
+func (c *Client) GetBlockByLimitNext(ctx context.Context, start int64, end int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getblockbylimitnext", GetBlockByLimitNextReq{StartNum: start, EndNum: end}, &out)
+	return out, err
+}
+
+func (c *Client) GetBlockByLatestNum(ctx context.Context, num int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getblockbylatestnum", GetBlockByNumReq{Num: num}, &out)
+	return out, err
+}
+
+type GetBlockBalanceReq struct {
+	Hash    string `json:"hash"`
+	Number  int64  `json:"number"`
+	Visible bool   `json:"visible,omitempty"`
+}
+
+func (c *Client) GetBlockBalance(ctx context.Context, block BlockIdentifier) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getblockbalance", GetBlockBalanceReq{
+		Hash:    block.Hash,
+		Number:  block.Number,
+		Visible: c.visible,
+	}, &out)
+	return out, err
+}
+
+func (c *Client) GetTransactionInfoByBlockNum(ctx context.Context, num int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "gettransactioninfobyblocknum", GetBlockByNumReq{Num: num}, &out)
+	return out, err
+}
+
+type countResp struct {
+	Count int64 `json:"count"`
+}
+
+func (c *Client) GetTransactionCountByBlockNum(ctx context.Context, num int64) (int64, error) {
+	var out countResp
+	err := c.Call(ctx, "gettransactioncountbyblocknum", GetBlockByNumReq{Num: num}, &out)
+	return out.Count, err
+}
+
+func (c *Client) GetTransactionFromPending(ctx context.Context, txID string) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "gettransactionfrompending", GetTransactionByIDReq{Value: txID}, &out)
+	return out, err
+}
+
+type pendingListResp struct {
+	TxID []string `json:"txId"`
+}
+
+// GetTransactionListFromPending returns the ids of the transactions in the
+// node's pending pool.
+func (c *Client) GetTransactionListFromPending(ctx context.Context) ([]string, error) {
+	var out pendingListResp
+	err := c.Call(ctx, "gettransactionlistfrompending", nil, &out)
+	return out.TxID, err
+}
+
+type pendingSizeResp struct {
+	PendingSize int64 `json:"pendingSize"`
+}
+
+func (c *Client) GetPendingSize(ctx context.Context) (int64, error) {
+	var out pendingSizeResp
+	err := c.Call(ctx, "getpendingsize", nil, &out)
+	return out.PendingSize, err
+}
+
+type BroadcastHexReq struct {
+	Transaction string `json:"transaction"`
+}
+
+// BroadcastHex broadcasts a signed transaction in its protobuf hex encoding.
+func (c *Client) BroadcastHex(ctx context.Context, txHex string) (*BroadcastResp, error) {
+	var out BroadcastResp
+	if err := c.Call(ctx, "broadcasthex", BroadcastHexReq{Transaction: txHex}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// EstimateEnergy returns the energy a contract call would use. Nodes only
+// serve it when vm.estimateEnergy is enabled.
+func (c *Client) EstimateEnergy(ctx context.Context, req TriggerConstantContractReq) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "estimateenergy", req, &out)
+	return out, err
+}
+
+type GetContractReq struct {
+	Value   Address `json:"value"`
+	Visible bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) GetContract(ctx context.Context, address Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getcontract", GetContractReq{Value: address, Visible: c.visible}, &out)
+	return out, err
+}
+
+func (c *Client) GetContractInfo(ctx context.Context, address Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getcontractinfo", GetContractReq{Value: address, Visible: c.visible}, &out)
+	return out, err
+}
+
+type DeployContractReq struct {
+	OwnerAddress               Address `json:"owner_address"`
+	Name                       string  `json:"name,omitempty"`
+	ABI                        string  `json:"abi,omitempty"`
+	Bytecode                   string  `json:"bytecode"`
+	Parameter                  string  `json:"parameter,omitempty"`
+	CallValue                  int64   `json:"call_value,omitempty"`
+	FeeLimit                   int64   `json:"fee_limit"`
+	ConsumeUserResourcePercent int64   `json:"consume_user_resource_percent"`
+	OriginEnergyLimit          int64   `json:"origin_energy_limit"`
+	PermissionID               int     `json:"Permission_id,omitempty"`
+	Visible                    bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) DeployContract(ctx context.Context, req DeployContractReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "deploycontract", req, &out)
+	return out, err
+}
+
+type UpdateSettingReq struct {
+	OwnerAddress               Address `json:"owner_address"`
+	ContractAddress            Address `json:"contract_address"`
+	ConsumeUserResourcePercent int64   `json:"consume_user_resource_percent"`
+	PermissionID               int     `json:"Permission_id,omitempty"`
+	Visible                    bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) UpdateSetting(ctx context.Context, req UpdateSettingReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "updatesetting", req, &out)
+	return out, err
+}
+
+type UpdateEnergyLimitReq struct {
+	OwnerAddress      Address `json:"owner_address"`
+	ContractAddress   Address `json:"contract_address"`
+	OriginEnergyLimit int64   `json:"origin_energy_limit"`
+	PermissionID      int     `json:"Permission_id,omitempty"`
+	Visible           bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) UpdateEnergyLimit(ctx context.Context, req UpdateEnergyLimitReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "updateenergylimit", req, &out)
+	return out, err
+}
+
+type ClearABIReq struct {
+	OwnerAddress    Address `json:"owner_address"`
+	ContractAddress Address `json:"contract_address"`
+	PermissionID    int     `json:"Permission_id,omitempty"`
+	Visible         bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) ClearABI(ctx context.Context, req ClearABIReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "clearabi", req, &out)
+	return out, err
+}
+
+func (c *Client) ListNodes(ctx context.Context) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "listnodes", nil, &out)
+	return out, err
+}
@@ -0,0 +1,372 @@
+package tron
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores raw node responses by key. Implementations must be safe for
+// concurrent use; a Redis or memcached adapter only needs these two methods.
+// Set with a zero ttl stores value until it is evicted; otherwise Get must
+// stop returning it once ttl has passed.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// assetIssueTTL bounds how long TRC10 asset metadata is cached, as the owner
+// can change its description, URL and limits with UpdateAssetContract.
+const assetIssueTTL = time.Hour
+
+// WithCache caches responses that can no longer change: solidified blocks,
+// transactions and transaction info, and TRC20 name/symbol/decimals. TRC10
+// asset metadata is cached for an hour. It also enables request coalescing.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.coalesce = true
+	}
+}
+
+// WithRequestCoalescing makes concurrent identical read calls share a single
+// node request; calls with different ContextWithHeader headers are not
+// identical. The shared request carries the first caller's context values
+// but not its cancellation or deadline, so it runs until the node answers or
+// the HTTP client times out, while each caller returns as soon as its own
+// context is done.
+func WithRequestCoalescing() Option {
+	return func(c *Client) { c.coalesce = true }
+}
+
+// immutableSelectors are constant calls whose results are fixed for the life
+// of a token contract.
+var immutableSelectors = map[string]bool{
+	"name()":     true,
+	"symbol()":   true,
+	"decimals()": true,
+}
+
+// cacheKey identifies a call by endpoint, request body and the headers set
+// with ContextWithHeader, since those may change what the node returns.
+func (c *Client) cacheKey(ctx context.Context, path string, req any) (string, error) {
+	body := []byte("{}")
+	if req != nil {
+		var err error
+		if body, err = json.Marshal(req); err != nil {
+			return "", err
+		}
+	}
+
+	h := sha256.New()
+	h.Write([]byte(c.baseURL + "/" + path + "\n"))
+	if header, ok := ctx.Value(callHeaderKey{}).(http.Header); ok {
+		for _, k := range slices.Sorted(maps.Keys(header)) {
+			fmt.Fprintf(h, "%s: %q\n", k, header[k])
+		}
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (c *Client) observeSolidHeight(num int64) {
+	for {
+		cur := c.solidHeight.Load()
+		if num <= cur || c.solidHeight.CompareAndSwap(cur, num) {
+			return
+		}
+	}
+}
+
+// cacheTTL reports whether a response can be cached and for how long, zero
+// meaning forever. Responses from the solidity API also advance the known
+// solid height, which lets full-node responses for older blocks be cached as
+// well.
+func (c *Client) cacheTTL(method string, solid bool, req any, raw []byte) (time.Duration, bool) {
+	switch method {
+	case "getnowblock", "getblockbynum", "getblockbyid":
+		var b struct {
+			BlockID     string `json:"blockID"`
+			BlockHeader struct {
+				RawData struct {
+					Number int64 `json:"number"`
+				} `json:"raw_data"`
+			} `json:"block_header"`
+		}
+		if json.Unmarshal(raw, &b) != nil || b.BlockID == "" {
+			return 0, false
+		}
+		num := b.BlockHeader.RawData.Number
+		if solid {
+			c.observeSolidHeight(num)
+		}
+		return 0, method != "getnowblock" && (solid || num <= c.solidHeight.Load())
+
+	case "gettransactioninfobyid":
+		var info struct {
+			BlockNumber int64 `json:"blockNumber"`
+		}
+		if json.Unmarshal(raw, &info) != nil || info.BlockNumber == 0 {
+			return 0, false
+		}
+		if solid {
+			c.observeSolidHeight(info.BlockNumber)
+			return 0, true
+		}
+		return 0, info.BlockNumber <= c.solidHeight.Load()
+
+	case "gettransactionbyid":
+		var tx struct {
+			TxID string `json:"txID"`
+		}
+		return 0, solid && json.Unmarshal(raw, &tx) == nil && tx.TxID != ""
+
+	case "getassetissuebyid":
+		var asset struct {
+			ID string `json:"id"`
+		}
+		return assetIssueTTL, json.Unmarshal(raw, &asset) == nil && asset.ID != ""
+
+	case "triggerconstantcontract":
+		r, ok := req.(TriggerConstantContractReq)
+		if !ok || !immutableSelectors[r.Function] {
+			return 0, false
+		}
+		var out TriggerConstResult
+		return 0, json.Unmarshal(raw, &out) == nil && out.Result.Result && len(out.ConstantResult) > 0
+	}
+	return 0, false
+}
+
+// callCached serves a read call from the cache or from a coalesced node
+// request, then stores the response if cacheTTL allows.
+func (c *Client) callCached(ctx context.Context, method string, path string, req any, out any) error {
+	key, err := c.cacheKey(ctx, path, req)
+	if err != nil {
+		return err
+	}
+
+	if c.cache != nil {
+		raw, ok, err := c.cache.Get(ctx, key)
+		if err != nil {
+			c.logger.WarnContext(ctx, "tron cache get failed", "path", path, "error", err)
+		} else if ok {
+			return json.Unmarshal(raw, out)
+		}
+	}
+
+	raw, err := c.flights.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		var raw json.RawMessage
+		if err := c.callWithRetry(ctx, http.MethodPost, method, path, req, &raw); err != nil {
+			return nil, err
+		}
+
+		solid := strings.HasPrefix(path, "walletsolidity/")
+		if ttl, ok := c.cacheTTL(method, solid, req, raw); ok && c.cache != nil {
+			if err := c.cache.Set(ctx, key, raw, ttl); err != nil {
+				c.logger.WarnContext(ctx, "tron cache set failed", "path", path, "error", err)
+			}
+		}
+		return raw, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("unmarshal response: %w", err)
+	}
+	return nil
+}
+
+type flight struct {
+	done chan struct{}
+	val  []byte
+	err  error
+}
+
+// flightGroup coalesces concurrent calls with the same key. The shared call
+// runs detached from any one caller's cancellation, so a caller giving up
+// does not fail the others; each caller still returns as soon as its own ctx
+// is done.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, ok := g.flights[key]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		g.flights[key] = f
+		go func() {
+			f.val, f.err = fn(context.WithoutCancel(ctx))
+
+			g.mu.Lock()
+			delete(g.flights, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.val, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LRUCache is an in-memory Cache holding at most size entries.
+type LRUCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func NewLRUCache(size int) *LRUCache {
+	return &LRUCache{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (l *LRUCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := e.Value.(*lruEntry)
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		l.order.Remove(e)
+		delete(l.entries, key)
+		return nil, false, nil
+	}
+	l.order.MoveToFront(e)
+	return entry.value, true, nil
+}
+
+func (l *LRUCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if e, ok := l.entries[key]; ok {
+		entry := e.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		l.order.MoveToFront(e)
+		return nil
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	if l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+	return nil
+}
+
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// DirCache is a Cache keeping one file per entry in a directory, so cached
+// responses survive restarts. Each file starts with a line holding the
+// entry's expiry in Unix nanoseconds, 0 for none.
+type DirCache struct {
+	dir string
+}
+
+func NewDirCache(dir string) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &DirCache{dir: dir}, nil
+}
+
+func (d *DirCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	path := filepath.Join(d.dir, key)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	line, value, ok := bytes.Cut(b, []byte("\n"))
+	if !ok {
+		return nil, false, nil
+	}
+	expires, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil {
+		return nil, false, nil
+	}
+	if expires != 0 && time.Now().UnixNano() > expires {
+		os.Remove(path)
+		return nil, false, nil
+	}
+	return value, true, nil
+}
+
+func (d *DirCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+
+	f, err := os.CreateTemp(d.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(strconv.AppendInt(nil, expires, 10), '\n'))
+	if err == nil {
+		_, err = f.Write(value)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(d.dir, key))
+}
@@ -0,0 +1,93 @@
+package tron_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// ttlCache is an LRUCache that remembers the ttl of every Set.
+type ttlCache struct {
+	*tron.LRUCache
+	mu   sync.Mutex
+	ttls []time.Duration
+}
+
+func (c *ttlCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	c.ttls = append(c.ttls, ttl)
+	c.mu.Unlock()
+	return c.LRUCache.Set(ctx, key, value, ttl)
+}
+
+func TestCacheKeyHeaders(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	token := deploy(t, node, mockTRC20(false), nil)
+	var calls atomic.Int64
+	c := node.Client(tron.WithCache(tron.NewLRUCache(16)), tron.WithInterceptor(countCalls(&calls, nil)))
+
+	tenantA := tron.ContextWithHeader(ctx, "X-Tenant", "a")
+	tenantB := tron.ContextWithHeader(ctx, "X-Tenant", "b")
+	for _, ctx := range []context.Context{tenantA, tenantA, tenantB, tenantB, ctx} {
+		if d, err := c.NewTRC20(token).Decimals(ctx); err != nil || d != 6 {
+			t.Fatalf("decimals = %d, %v", d, err)
+		}
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("node calls = %d, want one per distinct header set", n)
+	}
+}
+
+func TestCacheAssetIssueTTL(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	issuer := trontest.NewKey("issuer").Address
+	id := node.IssueAsset(tron.AssetIssue{OwnerAddress: issuer, Name: "Gold", Abbr: "GLD", TotalSupply: 1_000})
+	cache := &ttlCache{LRUCache: tron.NewLRUCache(16)}
+	c := node.Client(tron.WithCache(cache))
+
+	if _, err := c.GetAssetIssueByID(ctx, id); err != nil {
+		t.Fatalf("get asset: %v", err)
+	}
+	if len(cache.ttls) != 1 || cache.ttls[0] != time.Hour {
+		t.Fatalf("cached with ttls %v, want [1h]", cache.ttls)
+	}
+}
+
+func TestCacheExpiry(t *testing.T) {
+	ctx := context.Background()
+	dir, err := tron.NewDirCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, cache := range map[string]tron.Cache{"LRUCache": tron.NewLRUCache(16), "DirCache": dir} {
+		if err := cache.Set(ctx, "forever", []byte(`{"a":1}`), 0); err != nil {
+			t.Fatalf("%s: set: %v", name, err)
+		}
+		if err := cache.Set(ctx, "brief", []byte(`{"b":2}`), time.Millisecond); err != nil {
+			t.Fatalf("%s: set: %v", name, err)
+		}
+		if v, ok, err := cache.Get(ctx, "brief"); err != nil || !ok || string(v) != `{"b":2}` {
+			t.Fatalf("%s: get before expiry = %s, %v, %v", name, v, ok, err)
+		}
+
+		time.Sleep(5 * time.Millisecond)
+		if _, ok, err := cache.Get(ctx, "brief"); err != nil || ok {
+			t.Fatalf("%s: get after expiry = %v, %v; want a miss", name, ok, err)
+		}
+		if v, ok, err := cache.Get(ctx, "forever"); err != nil || !ok || string(v) != `{"a":1}` {
+			t.Fatalf("%s: get without ttl = %s, %v, %v", name, v, ok, err)
+		}
+	}
+}
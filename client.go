@@ -7,19 +7,42 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
 type Option func(*Client)
 
+// Invoker performs a single node call. path is the resolved endpoint, e.g.
+// "walletsolidity/getaccount"; out is the pointer the response is decoded into.
+// CallInfo.HTTPMethod says how req is sent: as a JSON body for POST, or as
+// the query of a GET, in which case req is url.Values (TronGrid v1 API).
+type Invoker func(ctx context.Context, path string, req any, out any) error
+
+// Interceptor wraps an Invoker to add cross-cutting behaviour such as
+// logging, metrics or caching. It runs once per attempt, inside the retry
+// loop; CallAttempt reports which attempt is in flight.
+type Interceptor func(next Invoker) Invoker
+
 type Client struct {
 	baseURL string
 	hc      *http.Client
 	headers http.Header
 
+	interceptors []Interceptor
+	invoke       Invoker
+	logger       *slog.Logger
+
+	cache       Cache
+	coalesce    bool
+	flights     flightGroup
+	solidHeight atomic.Int64
+
 	retryN      int
 	retryWait   time.Duration
 	maxBodySize int64
@@ -47,11 +70,17 @@ func New(baseURL string, opts ...Option) *Client {
 		maxBodySize: 4 << 20,
 		visible:     true,
 		solid:       false,
+		logger:      slog.New(slog.DiscardHandler),
 	}
 
 	for _, opt := range opts {
 		opt(c)
 	}
+
+	c.invoke = c.invokeOnce
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		c.invoke = c.interceptors[i](c.invoke)
+	}
 	return c
 }
 
@@ -71,6 +100,50 @@ func WithSolid(solid bool) Option {
 	return func(c *Client) { c.solid = solid }
 }
 
+// WithInterceptor appends interceptors to the call chain. The first one
+// registered is the outermost.
+func WithInterceptor(interceptors ...Interceptor) Option {
+	return func(c *Client) { c.interceptors = append(c.interceptors, interceptors...) }
+}
+
+// CallInfo describes the call in flight, for use in interceptors.
+type CallInfo struct {
+	Method     string // e.g. "getaccount"
+	Path       string // e.g. "walletsolidity/getaccount"
+	HTTPMethod string // http.MethodPost, or http.MethodGet for TronGrid v1
+	Endpoint   string // base URL of the node
+	Solid      bool   // whether the call goes to the solidity API
+	Attempt    int    // zero-based retry attempt
+}
+
+type callInfoKey struct{}
+type callHeaderKey struct{}
+
+func CallInfoFromContext(ctx context.Context) (CallInfo, bool) {
+	info, ok := ctx.Value(callInfoKey{}).(CallInfo)
+	return info, ok
+}
+
+// CallAttempt returns the zero-based attempt number of the call in flight.
+func CallAttempt(ctx context.Context) int {
+	info, _ := CallInfoFromContext(ctx)
+	return info.Attempt
+}
+
+// ContextWithHeader adds a header to the HTTP request of calls made with ctx,
+// e.g. from an interceptor that injects per-call credentials.
+func ContextWithHeader(ctx context.Context, key, value string) context.Context {
+	h := make(http.Header)
+	if prev, ok := ctx.Value(callHeaderKey{}).(http.Header); ok {
+		h = prev.Clone()
+	}
+	h.Set(key, value)
+	return context.WithValue(ctx, callHeaderKey{}, h)
+}
+
+// WithRetry sets how many times a call failing with a 5xx status or a network
+// error is retried, waiting wait between attempts. Broadcasts are never
+// retried.
 func WithRetry(n int, wait time.Duration) Option {
 	return func(c *Client) {
 		c.retryN = n
@@ -78,6 +151,8 @@ func WithRetry(n int, wait time.Duration) Option {
 	}
 }
 
+// APIError is a non-2xx response. Error includes the body as received; logs
+// redact it.
 type APIError struct {
 	StatusCode int
 	Body       string
@@ -87,18 +162,20 @@ func (e *APIError) Error() string {
 	return fmt.Sprintf("tron api error: status=%d body=%s", e.StatusCode, e.Body)
 }
 
-var canBeUsedSolidMethods = map[string]bool{
-	"getnowblock":             true,
-	"getblockbynum":           true,
-	"getblockbyid":            true,
-	"gettransactionbyid":      true,
-	"gettransactioninfobyid":  true,
-	"getaccount":              true,
-	"triggerconstantcontract": true,
+// DecodeError is returned when a successful response cannot be decoded into
+// the call's result. Body is kept out of Error.
+type DecodeError struct {
+	StatusCode int
+	Body       string
+	Err        error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("unmarshal response: %v", e.Err)
+}
 
-	"broadcasttransaction": false,
-	"createtransaction":    false,
-	"triggersmartcontract": false,
+func (e *DecodeError) Unwrap() error {
+	return e.Err
 }
 
 func (c *Client) Call(ctx context.Context, methodPath string, req any, out any) error {
@@ -106,45 +183,56 @@ func (c *Client) Call(ctx context.Context, methodPath string, req any, out any)
 		return errors.New("out must not be nil")
 	}
 
-	canBeUsed, ok := canBeUsedSolidMethods[methodPath]
+	m, ok := LookupMethod(methodPath)
 	if !ok {
-		return errors.New("method not found")
+		return fmt.Errorf("method %q not found", methodPath)
 	}
 
-	var path string
-	if c.solid {
-		if !canBeUsed {
-			path = "wallet/" + methodPath
-		} else {
-			path = "walletsolidity/" + methodPath
-		}
-	} else {
-		path = "wallet/" + methodPath
+	path := "wallet/" + methodPath
+	if c.solid && m.Solidity {
+		path = "walletsolidity/" + methodPath
 	}
 
-	url := c.baseURL + "/" + path
+	if m.Idempotent && (c.coalesce || c.cache != nil) {
+		return c.callCached(ctx, methodPath, path, req, out)
+	}
+	return c.callWithRetry(ctx, http.MethodPost, methodPath, path, req, out)
+}
 
-	var body []byte
-	var err error
-	if req == nil {
-		body = []byte("{}")
-	} else {
-		body, err = json.Marshal(req)
-		if err != nil {
-			return fmt.Errorf("marshal request: %w", err)
-		}
+func (c *Client) callWithRetry(ctx context.Context, httpMethod string, methodPath string, path string, req any, out any) error {
+	info := CallInfo{
+		Method:     methodPath,
+		Path:       path,
+		HTTPMethod: httpMethod,
+		Endpoint:   c.baseURL,
+		Solid:      strings.HasPrefix(path, "walletsolidity/"),
+	}
+
+	// A broadcast that timed out may still have reached the network, and
+	// sending it again turns a success into DUP_TRANSACTION_ERROR.
+	retryN := c.retryN
+	if m, ok := LookupMethod(methodPath); ok && m.Broadcast {
+		retryN = 0
 	}
 
 	var lastErr error
-	for attempt := 0; attempt <= c.retryN; attempt++ {
-		lastErr = c.doOnce(ctx, url, body, out)
+	for attempt := 0; attempt <= retryN; attempt++ {
+		info.Attempt = attempt
+		start := time.Now()
+		lastErr = c.invoke(context.WithValue(ctx, callInfoKey{}, info), path, req, out)
 		if lastErr == nil {
+			c.logger.DebugContext(ctx, "tron call succeeded",
+				"path", path, "attempt", attempt, "duration", time.Since(start))
 			return nil
 		}
 
-		if !shouldRetry(lastErr) || attempt == c.retryN {
+		if !shouldRetry(lastErr) || attempt == retryN {
+			c.logger.ErrorContext(ctx, "tron call failed",
+				"path", path, "attempt", attempt, "duration", time.Since(start), errorAttrs(lastErr))
 			return lastErr
 		}
+		c.logger.WarnContext(ctx, "tron call failed, retrying",
+			"path", path, "attempt", attempt, "duration", time.Since(start), "retry_in", c.retryWait, errorAttrs(lastErr))
 
 		select {
 		case <-ctx.Done():
@@ -156,17 +244,52 @@ func (c *Client) Call(ctx context.Context, methodPath string, req any, out any)
 	return lastErr
 }
 
-func (c *Client) doOnce(ctx context.Context, url string, body []byte, out any) error {
-	r, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+func (c *Client) invokeOnce(ctx context.Context, path string, req any, out any) error {
+	info, _ := CallInfoFromContext(ctx)
+	method, target := info.HTTPMethod, c.baseURL+"/"+path
+	var body []byte
+	var err error
+	switch {
+	case method == http.MethodGet:
+		query, ok := req.(url.Values)
+		if !ok && req != nil {
+			return fmt.Errorf("GET request must be url.Values, got %T", req)
+		}
+		if len(query) > 0 {
+			target += "?" + query.Encode()
+		}
+	case method != http.MethodPost:
+		return fmt.Errorf("unsupported HTTP method %q", method)
+	case req == nil:
+		body = []byte("{}")
+	default:
+		body, err = json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(body))
 	if err != nil {
 		return err
 	}
-	r.Header.Set("Content-Type", "application/json")
+	if method == http.MethodPost {
+		r.Header.Set("Content-Type", "application/json")
+	}
 	for k, vv := range c.headers {
 		for _, v := range vv {
 			r.Header.Add(k, v)
 		}
 	}
+	if h, ok := ctx.Value(callHeaderKey{}).(http.Header); ok {
+		for k, vv := range h {
+			r.Header[k] = vv
+		}
+	}
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		c.logger.DebugContext(ctx, "tron request",
+			"path", path, "method", method, "headers", redactHeader(r.Header), "body", logBody(body))
+	}
 
 	resp, err := c.hc.Do(r)
 	if err != nil {
@@ -179,17 +302,39 @@ func (c *Client) doOnce(ctx context.Context, url string, body []byte, out any) e
 	if err != nil {
 		return err
 	}
+	if c.logger.Enabled(ctx, slog.LevelDebug) {
+		c.logger.DebugContext(ctx, "tron response",
+			"path", path, "status", resp.StatusCode, "size", len(b), "body", logBody(b))
+	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		return &APIError{StatusCode: resp.StatusCode, Body: string(b)}
 	}
 
 	if err := json.Unmarshal(b, out); err != nil {
-		return fmt.Errorf("unmarshal response: %w; body=%s", err, string(b))
+		return &DecodeError{StatusCode: resp.StatusCode, Body: string(b), Err: err}
 	}
 	return nil
 }
 
+// StatusCode returns the HTTP status of a finished call: 200 on success, the
+// node's status for an APIError or DecodeError and 0 when no response was
+// received.
+func StatusCode(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return decodeErr.StatusCode
+	}
+	return 0
+}
+
 func shouldRetry(err error) bool {
 	var apiErr *APIError
 	if errors.As(err, &apiErr) {
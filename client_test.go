@@ -0,0 +1,217 @@
+package tron_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestTransferTRX(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 100_000_000)
+	c := node.Client()
+
+	tx, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1_500_000))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, alice.PrivateKey)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	signers, err := tron.VerifyTransaction(signed)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if len(signers) != 1 || signers[0] != alice.Address.String() {
+		t.Fatalf("signers = %v, want [%s]", signers, alice.Address)
+	}
+
+	resp, err := c.BroadcastTransaction(ctx, signed)
+	if err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if !resp.Result {
+		t.Fatalf("broadcast rejected: %s %s", resp.Code, resp.Message)
+	}
+
+	resp, err = c.BroadcastTransaction(ctx, signed)
+	if err != nil {
+		t.Fatalf("rebroadcast: %v", err)
+	}
+	if resp.Result || resp.Code != "DUP_TRANSACTION_ERROR" {
+		t.Fatalf("rebroadcast = %+v, want DUP_TRANSACTION_ERROR", resp)
+	}
+
+	node.ProduceBlock()
+	if got := node.Balance(bob.Address); got != 1_500_000 {
+		t.Fatalf("bob balance = %d, want 1500000", got)
+	}
+
+	check, err := c.VerifyTransactionPermissions(ctx, signed)
+	if err != nil {
+		t.Fatalf("verify permissions: %v", err)
+	}
+	if check.Owner != alice.Address || !check.ThresholdMet() {
+		t.Fatalf("permission check = %+v, want threshold met for %s", check, alice.Address)
+	}
+}
+
+func TestTransferTRXRejected(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 1_000_000)
+	c := node.Client()
+
+	// Both transfers fit the balance when built, only one when applied.
+	first, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(600_000))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	second, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(700_000))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	send(t, c, first, alice)
+
+	byBob, err := tron.SignTransaction(second, bob.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byAlice, err := tron.SignTransaction(second, alice.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		signed []byte
+		code   string
+	}{
+		{byBob, "SIGERROR"},
+		{byAlice, "CONTRACT_VALIDATE_ERROR"},
+	} {
+		resp, err := c.BroadcastTransaction(ctx, tc.signed)
+		if err != nil {
+			t.Fatalf("broadcast: %v", err)
+		}
+		if resp.Result || resp.Code != tc.code {
+			t.Fatalf("broadcast = %+v, want %s", resp, tc.code)
+		}
+	}
+	if got := node.Balance(bob.Address); got != 600_000 {
+		t.Fatalf("bob balance = %d, want 600000", got)
+	}
+}
+
+func TestConstantCall(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	// Runtime code that returns 42 for any call.
+	code, _ := hex.DecodeString("602a60005260206000f3")
+	token := trontest.NewKey("token").Address
+	node.SetCode(token, code)
+
+	holder := trontest.NewKey("holder").Address
+	balance, err := node.Client().NewTRC20(token).BalanceOf(ctx, holder)
+	if err != nil {
+		t.Fatalf("balanceOf: %v", err)
+	}
+	if balance.Int64() != 42 {
+		t.Fatalf("balanceOf = %s, want 42", balance)
+	}
+}
+
+func TestConstantCallErrors(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	reverts := trontest.NewKey("reverts").Address
+	node.SetCode(reverts, []byte{0x60, 0x00, 0x80, 0xfd}) // PUSH1 0, DUP1, REVERT
+	c := node.Client()
+
+	for contract, code := range map[tron.Address]string{
+		reverts:                         "CONTRACT_EXE_ERROR",
+		trontest.NewKey("none").Address: "CONTRACT_VALIDATE_ERROR",
+	} {
+		_, err := c.NewTRC20(contract).BalanceOf(ctx, contract)
+		var callErr *tron.ContractCallError
+		if !errors.As(err, &callErr) || callErr.Code != code {
+			t.Fatalf("balanceOf on %s: err = %v, want %s", contract, err, code)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 100_000_000)
+
+	// Reads fail twice before reaching the node. Broadcasts reach it but the
+	// reply is lost, as when a proxy times out after forwarding.
+	var paths []string
+	transport := transportFunc(func(r *http.Request) (*http.Response, error) {
+		paths = append(paths, r.URL.Path)
+		unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: r}
+		switch {
+		case r.URL.Path == "/wallet/broadcasttransaction":
+			resp, err := http.DefaultTransport.RoundTrip(r)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body.Close()
+			return unavailable, nil
+		case r.URL.Path == "/wallet/getaccount" && len(paths) <= 2:
+			return unavailable, nil
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	c := node.Client(tron.WithHTTPClient(&http.Client{Transport: transport}), tron.WithRetry(2, 0))
+
+	if _, err := c.GetAccount(ctx, alice.Address); err != nil {
+		t.Fatalf("get account: %v", err)
+	}
+	if len(paths) != 3 {
+		t.Fatalf("getaccount sent %d requests, want 3", len(paths))
+	}
+
+	tx, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, alice.PrivateKey)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	paths = nil
+	if _, err := c.BroadcastTransaction(ctx, signed); tron.StatusCode(err) != http.StatusServiceUnavailable {
+		t.Fatalf("broadcast: %v", err)
+	}
+	// A retry would have been answered with DUP_TRANSACTION_ERROR even though
+	// the transfer went through.
+	if len(paths) != 1 {
+		t.Fatalf("broadcast sent %v, want a single request", paths)
+	}
+	node.ProduceBlock()
+	if got := node.Balance(bob.Address); got != 1_000_000 {
+		t.Fatalf("bob balance = %d, want 1000000", got)
+	}
+}
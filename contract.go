@@ -0,0 +1,169 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ContractCallError is returned when the node executed a constant call and
+// it failed, e.g. because the contract reverted.
+type ContractCallError struct {
+	Code    string
+	Message string
+}
+
+func (e *ContractCallError) Error() string {
+	switch {
+	case e.Message != "":
+		return e.Message
+	case e.Code != "":
+		return "constant call failed: " + e.Code
+	}
+	return "constant call failed"
+}
+
+// constantCall runs a read-only contract call and returns the raw ABI-encoded
+// return data.
+func (c *Client) constantCall(ctx context.Context, ownerFrom Address, contract Address, fn string, param string) ([]byte, error) {
+	raw, err := c.TriggerConstantContract(ctx, TriggerConstantContractReq{
+		OwnerAddress:    ownerFrom,
+		ContractAddress: contract,
+		Function:        fn,
+		Parameter:       param,
+		Visible:         c.visible,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out TriggerConstResult
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	if !out.Result.Result {
+		msg := out.Message
+		if msg == "" {
+			msg = out.Result.Message
+		}
+		return nil, &ContractCallError{Code: out.Result.Code, Message: msg}
+	}
+	if len(out.ConstantResult) == 0 {
+		return nil, errors.New("empty constant_result")
+	}
+
+	hexRet := strings.TrimSpace(out.ConstantResult[0])
+	hexRet = strings.TrimPrefix(hexRet, "0x")
+	hexRet = strings.TrimPrefix(hexRet, "0X")
+	return hex.DecodeString(hexRet)
+}
+
+// buildTriggerTx creates an unsigned triggersmartcontract transaction.
+func (c *Client) buildTriggerTx(
+	ctx context.Context,
+	ownerFrom Address,
+	contract Address,
+	fn string,
+	param string,
+	feeLimit int64,
+	opts ...TxOption,
+) (json.RawMessage, error) {
+	o := applyTxOptions(opts)
+	raw, err := c.TriggerSmartContract(ctx, TriggerSmartContractReq{
+		OwnerAddress:    ownerFrom,
+		ContractAddress: contract,
+		Function:        fn,
+		Parameter:       param,
+		FeeLimit:        feeLimit,
+		PermissionID:    o.permissionID,
+		Visible:         c.visible,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out TriggerSmartResult
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	if !out.Result.Result {
+		if out.Message != "" {
+			return nil, errors.New(out.Message)
+		}
+		return nil, errors.New("triggersmartcontract failed")
+	}
+	if len(out.Transaction) == 0 {
+		return nil, errors.New("empty transaction")
+	}
+	return out.Transaction, nil
+}
+
+func mustParseABI(abiJSON string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}
+
+// callABI runs a read-only call of an ABI method and returns its unpacked
+// outputs.
+func (c *Client) callABI(
+	ctx context.Context,
+	ownerFrom Address,
+	contract Address,
+	contractABI *abi.ABI,
+	method string,
+	args ...any,
+) ([]any, error) {
+	m, ok := contractABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in abi", method)
+	}
+
+	input, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: %w", method, err)
+	}
+
+	ret, err := c.constantCall(ctx, ownerFrom, contract, m.Sig, hex.EncodeToString(input[4:]))
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := m.Outputs.Unpack(ret)
+	if err != nil {
+		return nil, fmt.Errorf("unpack %s: %w", method, err)
+	}
+	if len(out) != len(m.Outputs) {
+		return nil, fmt.Errorf("unpack %s: got %d values, want %d", method, len(out), len(m.Outputs))
+	}
+	return out, nil
+}
+
+func (c *Client) buildABITx(
+	ctx context.Context,
+	ownerFrom Address,
+	contract Address,
+	contractABI *abi.ABI,
+	method string,
+	feeLimit int64,
+	args ...any,
+) (json.RawMessage, error) {
+	m, ok := contractABI.Methods[method]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found in abi", method)
+	}
+
+	input, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: %w", method, err)
+	}
+
+	return c.buildTriggerTx(ctx, ownerFrom, contract, m.Sig, hex.EncodeToString(input[4:]), feeLimit)
+}
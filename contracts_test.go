@@ -0,0 +1,503 @@
+package tron_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// asm assembles EVM bytecode for the mock contracts below. Labels are
+// JUMPDESTs; references to them are PUSH2 placeholders filled in by bytes.
+type asm struct {
+	code   []byte
+	labels map[string]int
+	refs   map[int]string
+}
+
+func newAsm() *asm {
+	return &asm{labels: make(map[string]int), refs: make(map[int]string)}
+}
+
+func (a *asm) op(ops ...vm.OpCode) *asm {
+	for _, op := range ops {
+		a.code = append(a.code, byte(op))
+	}
+	return a
+}
+
+// push emits the shortest PUSH for v: an int, a *big.Int or raw bytes.
+func (a *asm) push(v any) *asm {
+	var b []byte
+	switch v := v.(type) {
+	case int:
+		b = big.NewInt(int64(v)).Bytes()
+	case *big.Int:
+		b = v.Bytes()
+	case []byte:
+		b = v
+	default:
+		panic(fmt.Sprintf("asm: cannot push %T", v))
+	}
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+	if len(b) > 32 {
+		panic("asm: push wider than 32 bytes")
+	}
+	a.code = append(a.code, byte(vm.PUSH1)+byte(len(b)-1))
+	a.code = append(a.code, b...)
+	return a
+}
+
+func (a *asm) label(name string) *asm {
+	a.labels[name] = len(a.code)
+	return a.op(vm.JUMPDEST)
+}
+
+func (a *asm) ref(name string) *asm {
+	a.code = append(a.code, byte(vm.PUSH2))
+	a.refs[len(a.code)] = name
+	a.code = append(a.code, 0, 0)
+	return a
+}
+
+func (a *asm) jump(name string) *asm {
+	return a.ref(name).op(vm.JUMP)
+}
+
+func (a *asm) jumpi(name string) *asm {
+	return a.ref(name).op(vm.JUMPI)
+}
+
+// dispatch jumps to name when the selector on top of the stack is sig's.
+func (a *asm) dispatch(sig, name string) *asm {
+	return a.op(vm.DUP1).push(selector(sig)).op(vm.EQ).jumpi(name)
+}
+
+// arg loads the i-th 32-byte call argument.
+func (a *asm) arg(i int) *asm {
+	return a.push(4 + 32*i).op(vm.CALLDATALOAD)
+}
+
+// retWord returns the value on top of the stack as one ABI word.
+func (a *asm) retWord() *asm {
+	return a.push(0).op(vm.MSTORE).push(32).push(0).op(vm.RETURN)
+}
+
+// retData returns b, which is padded to whole words.
+func (a *asm) retData(b []byte) *asm {
+	words := (len(b) + 31) / 32
+	padded := make([]byte, words*32)
+	copy(padded, b)
+	for i := range words {
+		a.push(padded[i*32 : (i+1)*32]).push(i * 32).op(vm.MSTORE)
+	}
+	return a.push(len(padded)).push(0).op(vm.RETURN)
+}
+
+// mapKey hashes the two values on top of the stack, top first, into a
+// storage slot for a nested mapping.
+func (a *asm) mapKey() *asm {
+	return a.push(0).op(vm.MSTORE).push(32).op(vm.MSTORE).push(64).push(0).op(vm.KECCAK256)
+}
+
+// load pushes the memory word at addr; store pops the top of the stack into
+// it. The Multicall3 mock keeps its loop state in memory this way.
+func (a *asm) load(addr int) *asm {
+	return a.push(addr).op(vm.MLOAD)
+}
+
+func (a *asm) store(addr int) *asm {
+	return a.push(addr).op(vm.MSTORE)
+}
+
+func (a *asm) bytes() []byte {
+	code := append([]byte(nil), a.code...)
+	for at, name := range a.refs {
+		pc, ok := a.labels[name]
+		if !ok {
+			panic("asm: unknown label " + name)
+		}
+		code[at], code[at+1] = byte(pc>>8), byte(pc)
+	}
+	return code
+}
+
+// abiPack ABI-encodes values of the given Solidity types.
+func abiPack(types []string, values ...any) []byte {
+	var args abi.Arguments
+	for _, ty := range types {
+		typ, err := abi.NewType(ty, "", nil)
+		if err != nil {
+			panic(err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	b, err := args.Pack(values...)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func selector(sig string) []byte {
+	return crypto.Keccak256([]byte(sig))[:4]
+}
+
+// slot is the storage key of a per-address value, e.g. a token balance.
+func slot(addr tron.Address) common.Hash {
+	return common.BytesToHash(addr.EVM().Bytes())
+}
+
+// pairSlot is the storage key asm.mapKey computes with x on top of y.
+func pairSlot(x, y tron.Address) common.Hash {
+	return crypto.Keccak256Hash(slot(x).Bytes(), slot(y).Bytes())
+}
+
+// deploy installs runtime through creation code that first writes storage,
+// so the contract starts with balances.
+func deploy(t *testing.T, node *trontest.Node, runtime []byte, storage map[common.Hash]common.Hash) tron.Address {
+	t.Helper()
+
+	init := newAsm()
+	for k, v := range storage {
+		init.push(v.Bytes()).push(k.Bytes()).op(vm.SSTORE)
+	}
+	// PUSH2 len, DUP1, PUSH2 offset, PUSH1 0, CODECOPY, PUSH1 0, RETURN
+	offset := len(init.code) + 3 + 1 + 3 + 2 + 1 + 2 + 1
+	init.code = append(init.code, byte(vm.PUSH2), byte(len(runtime)>>8), byte(len(runtime)))
+	init.op(vm.DUP1)
+	init.code = append(init.code, byte(vm.PUSH2), byte(offset>>8), byte(offset))
+	init.push(0).op(vm.CODECOPY).push(0).op(vm.RETURN)
+
+	addr, err := node.Deploy(trontest.NewKey("deployer").Address, append(init.bytes(), runtime...))
+	if err != nil {
+		t.Fatalf("deploy: %v", err)
+	}
+	return addr
+}
+
+// mockTRC20 keeps balances at slot(holder) and allowances at
+// pairSlot(owner, spender). Its name is "Mock Token" and its symbol "MOCK",
+// returned as bytes32 like older tokens do. With returnsFalse it mimics USDT
+// on TRON, whose transfer returns false even when it succeeds.
+func mockTRC20(returnsFalse bool) []byte {
+	a := newAsm()
+	a.push(0).op(vm.CALLDATALOAD).push(0xe0).op(vm.SHR)
+	a.dispatch("balanceOf(address)", "balanceOf")
+	a.dispatch("allowance(address,address)", "allowance")
+	a.dispatch("decimals()", "decimals")
+	a.dispatch("name()", "name")
+	a.dispatch("symbol()", "symbol")
+	a.dispatch("approve(address,uint256)", "approve")
+	a.dispatch("increaseAllowance(address,uint256)", "increaseAllowance")
+	a.dispatch("decreaseAllowance(address,uint256)", "decreaseAllowance")
+	a.dispatch("transfer(address,uint256)", "transfer")
+	a.dispatch("transferFrom(address,address,uint256)", "transferFrom")
+	a.label("revert").push(0).op(vm.DUP1, vm.REVERT)
+
+	a.label("balanceOf").arg(0).op(vm.SLOAD).retWord()
+	a.label("allowance").arg(1).arg(0).mapKey().op(vm.SLOAD).retWord()
+	a.label("decimals").push(6).retWord()
+	a.label("name").retData(abiPack([]string{"string"}, "Mock Token"))
+	a.label("symbol").retData([]byte("MOCK"))
+
+	a.label("approve").arg(1).arg(0).op(vm.CALLER).mapKey().op(vm.SSTORE).jump("ok")
+
+	// [key] -> [key, current allowance]
+	a.label("increaseAllowance").arg(0).op(vm.CALLER).mapKey().op(vm.DUP1, vm.SLOAD)
+	a.arg(1).op(vm.ADD, vm.SWAP1, vm.SSTORE).jump("ok")
+
+	a.label("decreaseAllowance").arg(0).op(vm.CALLER).mapKey().op(vm.DUP1, vm.SLOAD)
+	a.arg(1).op(vm.DUP2, vm.DUP2, vm.GT).jumpi("revert")
+	a.op(vm.SWAP1, vm.SUB, vm.SWAP1, vm.SSTORE).jump("ok")
+
+	a.label("transfer").ref("ok").op(vm.CALLER).arg(0).arg(1).jump("move")
+
+	a.label("transferFrom").op(vm.CALLER).arg(0).mapKey().op(vm.DUP1, vm.SLOAD)
+	a.arg(2).op(vm.DUP2, vm.DUP2, vm.GT).jumpi("revert")
+	a.op(vm.SWAP1, vm.SUB, vm.SWAP1, vm.SSTORE)
+	a.ref("ok").arg(0).arg(1).arg(2).jump("move")
+
+	// move: [ret, from, to, amount] -> jump to ret
+	a.label("move").op(vm.DUP3, vm.SLOAD, vm.DUP2, vm.DUP2, vm.LT).jumpi("revert")
+	a.op(vm.DUP2, vm.SWAP1, vm.SUB, vm.DUP4, vm.SSTORE)
+	a.op(vm.DUP2, vm.SLOAD, vm.ADD, vm.SWAP1, vm.SSTORE, vm.POP, vm.JUMP)
+
+	a.label("ok")
+	if returnsFalse {
+		a.push(0).retWord()
+	} else {
+		a.push(1).retWord()
+	}
+	return a.bytes()
+}
+
+// send signs tx with key, broadcasts it and returns its id.
+func send(t *testing.T, c *tron.Client, tx []byte, key trontest.Key) string {
+	t.Helper()
+
+	signed, err := tron.SignTransaction(tx, key.PrivateKey)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	resp, err := c.BroadcastTransaction(context.Background(), signed)
+	if err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if !resp.Result {
+		t.Fatalf("broadcast rejected: %s %s", resp.Code, resp.Message)
+	}
+	return resp.TxID
+}
+
+// word left-aligns b in a 32-byte word, as the ABI encodes bytesN.
+func word(b []byte) []byte {
+	w := make([]byte, 32)
+	copy(w, b)
+	return w
+}
+
+// nftSlot is the storage key of per-token value tag, e.g. the owner (1) or
+// the approved address (2) of id.
+func nftSlot(id int64, tag int64) common.Hash {
+	return crypto.Keccak256Hash(common.BigToHash(big.NewInt(id)).Bytes(), common.BigToHash(big.NewInt(tag)).Bytes())
+}
+
+// indexSlot is the storage key of owner's index-th token in the enumeration.
+func indexSlot(owner tron.Address, index int64) common.Hash {
+	h := crypto.Keccak256Hash(slot(owner).Bytes(), common.BigToHash(big.NewInt(index)).Bytes())
+	return common.BigToHash(new(big.Int).Add(h.Big(), big.NewInt(1)))
+}
+
+// mockTRC721 keeps balances at slot(holder), owners at nftSlot(id, 1),
+// approvals at nftSlot(id, 2) and operators at pairSlot(owner, operator).
+// The enumeration at indexSlot is fixed at deploy time; transfers do not
+// update it. It supports ERC-165 and TRC-721 only.
+func mockTRC721() []byte {
+	a := newAsm()
+	a.push(0).op(vm.CALLDATALOAD).push(0xe0).op(vm.SHR)
+	a.dispatch("balanceOf(address)", "balanceOf")
+	a.dispatch("ownerOf(uint256)", "ownerOf")
+	a.dispatch("tokenURI(uint256)", "tokenURI")
+	a.dispatch("tokenOfOwnerByIndex(address,uint256)", "tokenOfOwnerByIndex")
+	a.dispatch("getApproved(uint256)", "getApproved")
+	a.dispatch("isApprovedForAll(address,address)", "isApprovedForAll")
+	a.dispatch("supportsInterface(bytes4)", "supportsInterface")
+	a.dispatch("approve(address,uint256)", "approve")
+	a.dispatch("setApprovalForAll(address,bool)", "setApprovalForAll")
+	a.dispatch("safeTransferFrom(address,address,uint256)", "safeTransferFrom")
+	a.dispatch("safeTransferFrom(address,address,uint256,bytes)", "safeTransferFrom")
+	a.label("revert").push(0).op(vm.DUP1, vm.REVERT)
+
+	a.label("balanceOf").arg(0).op(vm.SLOAD).retWord()
+	a.label("ownerOf").push(1).arg(0).mapKey().op(vm.SLOAD, vm.DUP1, vm.ISZERO).jumpi("revert").retWord()
+	a.label("tokenURI").retData(abiPack([]string{"string"}, "ipfs://token"))
+	a.label("tokenOfOwnerByIndex").arg(1).arg(0).mapKey().push(1).op(vm.ADD, vm.SLOAD).retWord()
+	a.label("getApproved").push(2).arg(0).mapKey().op(vm.SLOAD).retWord()
+	a.label("isApprovedForAll").arg(1).arg(0).mapKey().op(vm.SLOAD).retWord()
+	a.label("supportsInterface").arg(0).op(vm.DUP1).push(word(tron.InterfaceIDERC165[:])).op(vm.EQ)
+	a.op(vm.SWAP1).push(word(tron.InterfaceIDTRC721[:])).op(vm.EQ, vm.OR).retWord()
+
+	a.label("approve").push(1).arg(1).mapKey().op(vm.SLOAD, vm.CALLER, vm.EQ, vm.ISZERO).jumpi("revert")
+	a.arg(0).push(2).arg(1).mapKey().op(vm.SSTORE, vm.STOP)
+
+	a.label("setApprovalForAll").arg(1).arg(0).op(vm.CALLER).mapKey().op(vm.SSTORE, vm.STOP)
+
+	// The caller must be the owner, the approved address or an operator.
+	a.label("safeTransferFrom").push(1).arg(2).mapKey().op(vm.SLOAD).arg(0).op(vm.EQ, vm.ISZERO).jumpi("revert")
+	a.op(vm.CALLER).arg(0).op(vm.EQ)
+	a.push(2).arg(2).mapKey().op(vm.SLOAD, vm.CALLER, vm.EQ, vm.OR)
+	a.op(vm.CALLER).arg(0).mapKey().op(vm.SLOAD, vm.OR, vm.ISZERO).jumpi("revert")
+	a.arg(1).push(1).arg(2).mapKey().op(vm.SSTORE)
+	a.push(0).push(2).arg(2).mapKey().op(vm.SSTORE)
+	a.push(1).arg(0).op(vm.SLOAD, vm.SUB).arg(0).op(vm.SSTORE)
+	a.arg(1).op(vm.SLOAD).push(1).op(vm.ADD).arg(1).op(vm.SSTORE, vm.STOP)
+	return a.bytes()
+}
+
+// nftStorage is the deploy-time storage of mockTRC721 with the given owner
+// of each token id.
+func nftStorage(owners map[int64]tron.Address) map[common.Hash]common.Hash {
+	storage := make(map[common.Hash]common.Hash)
+	count := make(map[tron.Address]int64)
+	for id := int64(1); id <= int64(len(owners)); id++ {
+		owner := owners[id]
+		storage[nftSlot(id, 1)] = slot(owner)
+		storage[indexSlot(owner, count[owner])] = common.BigToHash(big.NewInt(id))
+		count[owner]++
+	}
+	for owner, n := range count {
+		storage[slot(owner)] = common.BigToHash(big.NewInt(n))
+	}
+	return storage
+}
+
+var (
+	transferSingleTopic = crypto.Keccak256([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+// multiSlot is the storage key of holder's balance of token id in
+// mockTRC1155.
+func multiSlot(holder tron.Address, id int64) common.Hash {
+	return crypto.Keccak256Hash(slot(holder).Bytes(), common.BigToHash(big.NewInt(id)).Bytes())
+}
+
+// mockTRC1155 keeps balances at multiSlot(holder, id) and operators one past
+// pairSlot(owner, operator). Transfers emit TransferSingle and TransferBatch.
+func mockTRC1155() []byte {
+	a := newAsm()
+	a.push(0).op(vm.CALLDATALOAD).push(0xe0).op(vm.SHR)
+	a.dispatch("balanceOf(address,uint256)", "balanceOf")
+	a.dispatch("balanceOfBatch(address[],uint256[])", "balanceOfBatch")
+	a.dispatch("uri(uint256)", "uri")
+	a.dispatch("isApprovedForAll(address,address)", "isApprovedForAll")
+	a.dispatch("setApprovalForAll(address,bool)", "setApprovalForAll")
+	a.dispatch("safeTransferFrom(address,address,uint256,uint256,bytes)", "safeTransferFrom")
+	a.dispatch("safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)", "safeBatchTransferFrom")
+	a.label("revert").push(0).op(vm.DUP1, vm.REVERT)
+
+	a.label("balanceOf").arg(1).arg(0).mapKey().op(vm.SLOAD).retWord()
+	a.label("uri").retData(abiPack([]string{"string"}, "ipfs://{id}.json"))
+	a.label("isApprovedForAll").arg(1).arg(0).mapKey().push(1).op(vm.ADD, vm.SLOAD).retWord()
+	a.label("setApprovalForAll").arg(1).arg(0).op(vm.CALLER).mapKey().push(1).op(vm.ADD, vm.SSTORE, vm.STOP)
+
+	// [accounts, ids, n, i]; balances are written from memory offset 64.
+	a.label("balanceOfBatch").arg(0).push(4).op(vm.ADD).arg(1).push(4).op(vm.ADD)
+	a.op(vm.DUP2, vm.CALLDATALOAD).push(0)
+	a.label("balanceLoop").op(vm.DUP2, vm.DUP2, vm.LT, vm.ISZERO).jumpi("balanceDone")
+	a.op(vm.DUP1).push(32).op(vm.MUL, vm.DUP5, vm.ADD).push(32).op(vm.ADD, vm.CALLDATALOAD)
+	a.op(vm.DUP2).push(32).op(vm.MUL, vm.DUP5, vm.ADD).push(32).op(vm.ADD, vm.CALLDATALOAD)
+	a.op(vm.SWAP1).mapKey().op(vm.SLOAD)
+	a.op(vm.DUP2).push(32).op(vm.MUL).push(64).op(vm.ADD, vm.MSTORE)
+	a.push(1).op(vm.ADD).jump("balanceLoop")
+	// mapKey hashes from memory 0, so the array head goes in last.
+	a.label("balanceDone").push(32).push(0).op(vm.MSTORE, vm.DUP1).push(32).op(vm.MSTORE)
+	a.push(32).op(vm.MUL).push(64).op(vm.ADD).push(0).op(vm.RETURN)
+
+	// authorized: the caller is from or one of its operators.
+	a.label("authorize").op(vm.CALLER).arg(0).op(vm.EQ)
+	a.op(vm.CALLER).arg(0).mapKey().push(1).op(vm.ADD, vm.SLOAD, vm.OR, vm.ISZERO).jumpi("revert")
+	a.op(vm.JUMP)
+
+	a.label("safeTransferFrom").ref("single").jump("authorize")
+	a.label("single").arg(2).arg(0).mapKey().op(vm.DUP1, vm.SLOAD)
+	a.arg(3).op(vm.DUP2, vm.DUP2, vm.GT).jumpi("revert")
+	a.op(vm.SWAP1, vm.SUB, vm.SWAP1, vm.SSTORE)
+	a.arg(2).arg(1).mapKey().op(vm.DUP1, vm.SLOAD).arg(3).op(vm.ADD, vm.SWAP1, vm.SSTORE)
+	a.arg(2).push(0).op(vm.MSTORE).arg(3).push(32).op(vm.MSTORE)
+	a.arg(1).arg(0).op(vm.CALLER).push(transferSingleTopic).push(64).push(0).op(vm.LOG4, vm.STOP)
+
+	// [ids, amounts, n, i]
+	a.label("safeBatchTransferFrom").ref("batch").jump("authorize")
+	a.label("batch").arg(2).push(4).op(vm.ADD).arg(3).push(4).op(vm.ADD)
+	a.op(vm.DUP2, vm.CALLDATALOAD).push(0)
+	a.label("batchLoop").op(vm.DUP2, vm.DUP2, vm.LT, vm.ISZERO).jumpi("batchDone")
+	a.op(vm.DUP1).push(32).op(vm.MUL, vm.DUP5, vm.ADD).push(32).op(vm.ADD, vm.CALLDATALOAD)
+	a.op(vm.DUP2).push(32).op(vm.MUL, vm.DUP5, vm.ADD).push(32).op(vm.ADD, vm.CALLDATALOAD)
+	// [.., id, amount] -> debit from
+	a.op(vm.DUP2).arg(0).mapKey().op(vm.DUP1, vm.SLOAD)
+	a.op(vm.DUP3, vm.DUP2, vm.LT).jumpi("revert")
+	a.op(vm.DUP3, vm.SWAP1, vm.SUB, vm.SWAP1, vm.SSTORE)
+	// credit to
+	a.op(vm.SWAP1).arg(1).mapKey().op(vm.DUP1, vm.SLOAD, vm.DUP3, vm.ADD, vm.SWAP1, vm.SSTORE, vm.POP)
+	a.push(1).op(vm.ADD).jump("batchLoop")
+	// TransferBatch data: the ids and amounts arrays copied from calldata.
+	a.label("batchDone").push(0x40).push(0).op(vm.MSTORE)
+	a.op(vm.DUP2).push(32).op(vm.MUL).push(0x60).op(vm.ADD).push(32).op(vm.MSTORE)
+	a.op(vm.DUP2).push(32).op(vm.MUL).push(32).op(vm.ADD, vm.DUP5).push(64).op(vm.CALLDATACOPY)
+	a.op(vm.DUP2).push(32).op(vm.MUL).push(32).op(vm.ADD, vm.DUP4, vm.DUP4).push(32).op(vm.MUL).push(0x60).op(vm.ADD, vm.CALLDATACOPY)
+	a.arg(1).arg(0).op(vm.CALLER).push(transferBatchTopic)
+	a.op(vm.DUP6).push(64).op(vm.MUL).push(0x80).op(vm.ADD).push(0).op(vm.LOG4, vm.STOP)
+	return a.bytes()
+}
+
+// Memory layout of mockMulticall3: loop state, then the returned array at
+// mcOut with the method's head words just below it, and the call input
+// copied to mcScratch, clear of the results.
+const (
+	mcArr     = 0x100 // calldata offset of the calls array length
+	mcN       = 0x120
+	mcI       = 0x140
+	mcP       = 0x160 // where the next result is written
+	mcDataAt  = 0x180 // offset of the callData pointer within a call tuple
+	mcSuccess = 0x1a0 // whether results are (bool, bytes) tuples
+	mcRequire = 0x1c0 // 0: allow failures, 1: require success, 2: per call
+	mcOut     = 0x300
+	mcScratch = 0x10000
+)
+
+// mockMulticall3 implements aggregate, tryBlockAndAggregate and aggregate3
+// like Multicall3, plus getEthBalance, getBlockNumber and
+// getCurrentBlockTimestamp. The block hash it reports is zero.
+func mockMulticall3() []byte {
+	a := newAsm()
+	a.push(0).op(vm.CALLDATALOAD).push(0xe0).op(vm.SHR)
+	a.dispatch("aggregate((address,bytes)[])", "aggregate")
+	a.dispatch("tryBlockAndAggregate(bool,(address,bytes)[])", "tryBlockAndAggregate")
+	a.dispatch("aggregate3((address,bool,bytes)[])", "aggregate3")
+	a.dispatch("getEthBalance(address)", "getEthBalance")
+	a.dispatch("getBlockNumber()", "getBlockNumber")
+	a.dispatch("getCurrentBlockTimestamp()", "getCurrentBlockTimestamp")
+	a.label("revert").push(0).op(vm.DUP1, vm.REVERT)
+
+	a.label("getEthBalance").arg(0).op(vm.BALANCE).retWord()
+	a.label("getBlockNumber").op(vm.NUMBER).retWord()
+	a.label("getCurrentBlockTimestamp").op(vm.TIMESTAMP).retWord()
+
+	a.label("aggregate").arg(0).push(4).op(vm.ADD).store(mcArr)
+	a.push(32).store(mcDataAt).push(1).store(mcRequire)
+	a.ref("aggregateDone").jump("calls")
+	a.label("aggregateDone").op(vm.NUMBER).store(mcOut - 64).push(0x40).store(mcOut - 32)
+	a.push(mcOut - 64).load(mcP).op(vm.SUB).push(mcOut - 64).op(vm.RETURN)
+
+	a.label("tryBlockAndAggregate").arg(1).push(4).op(vm.ADD).store(mcArr)
+	a.push(32).store(mcDataAt).push(1).store(mcSuccess).arg(0).store(mcRequire)
+	a.ref("tryDone").jump("calls")
+	a.label("tryDone").op(vm.NUMBER).store(mcOut - 96).push(0x60).store(mcOut - 32)
+	a.push(mcOut - 96).load(mcP).op(vm.SUB).push(mcOut - 96).op(vm.RETURN)
+
+	a.label("aggregate3").arg(0).push(4).op(vm.ADD).store(mcArr)
+	a.push(64).store(mcDataAt).push(1).store(mcSuccess).push(2).store(mcRequire)
+	a.ref("aggregate3Done").jump("calls")
+	a.label("aggregate3Done").push(0x20).store(mcOut - 32)
+	a.push(mcOut - 32).load(mcP).op(vm.SUB).push(mcOut - 32).op(vm.RETURN)
+
+	// calls: [ret] -> jump to ret with the results array encoded at mcOut.
+	a.label("calls").load(mcArr).op(vm.CALLDATALOAD, vm.DUP1).store(mcN).store(mcOut)
+	a.load(mcN).push(32).op(vm.MUL).push(mcOut + 32).op(vm.ADD).store(mcP)
+	a.label("loop").load(mcN).load(mcI).op(vm.LT, vm.ISZERO).jumpi("callsDone")
+	// [ret, tuple, data]
+	a.load(mcArr).push(32).op(vm.ADD, vm.DUP1).load(mcI).push(32).op(vm.MUL, vm.ADD, vm.CALLDATALOAD, vm.ADD)
+	a.op(vm.DUP1, vm.DUP1).load(mcDataAt).op(vm.ADD, vm.CALLDATALOAD, vm.ADD)
+	// [ret, tuple, data, len]; the input goes to mcScratch.
+	a.op(vm.DUP1, vm.CALLDATALOAD, vm.DUP1, vm.DUP3).push(32).op(vm.ADD).push(mcScratch).op(vm.CALLDATACOPY)
+	a.push(0).push(0).op(vm.DUP3).push(mcScratch).push(0).op(vm.DUP8, vm.CALLDATALOAD, vm.GAS, vm.CALL)
+	// [ret, tuple, success]
+	a.op(vm.SWAP2, vm.POP, vm.POP, vm.DUP1).jumpi("called")
+	a.load(mcRequire).op(vm.ISZERO).jumpi("called")
+	a.load(mcRequire).push(1).op(vm.EQ).jumpi("revert")
+	a.op(vm.DUP2).push(32).op(vm.ADD, vm.CALLDATALOAD, vm.ISZERO).jumpi("revert")
+	// [ret, success]; offsets are relative to the first one.
+	a.label("called").op(vm.SWAP1, vm.POP)
+	a.push(mcOut+32).load(mcP).op(vm.SUB).load(mcI).push(32).op(vm.MUL).push(mcOut+32).op(vm.ADD, vm.MSTORE)
+	a.load(mcSuccess).op(vm.ISZERO).jumpi("data")
+	a.load(mcP).op(vm.MSTORE).push(0x40).load(mcP).push(32).op(vm.ADD, vm.MSTORE)
+	a.load(mcP).push(64).op(vm.ADD).store(mcP).push(0)
+	a.label("data").op(vm.POP, vm.RETURNDATASIZE).load(mcP).op(vm.MSTORE)
+	a.op(vm.RETURNDATASIZE).push(0).load(mcP).push(32).op(vm.ADD, vm.RETURNDATACOPY)
+	a.op(vm.RETURNDATASIZE).push(31).op(vm.ADD).push(32).op(vm.SWAP1, vm.DIV).push(32).op(vm.MUL)
+	a.push(32).op(vm.ADD).load(mcP).op(vm.ADD).store(mcP)
+	a.load(mcI).push(1).op(vm.ADD).store(mcI).jump("loop")
+	a.label("callsDone").op(vm.JUMP)
+	return a.bytes()
+}
@@ -1,16 +1,22 @@
 package tron
 
-import "math/big"
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
 
 const (
 	TrxDecimals = 6
 )
 
+// Deprecated: float64 cannot represent large amounts exactly; use Amount.
 func BigIntToFloat64(n *big.Int) float64 {
 	f, _ := n.Float64()
 	return f
 }
 
+// Deprecated: truncates the fractional part; use ParseAmount.
 func Float64ToBigInt(f float64) *big.Int {
 	return big.NewInt(int64(f))
 }
@@ -30,17 +36,15 @@ func SunToTRX(sunValue *big.Int) *big.Float {
 	return sunBigFloat.Quo(sunBigFloat, decimalsBigFloat)
 }
 
+// TRXToSun returns nil when trxValue is nil or infinite; use TRXToSunChecked
+// to get the reason.
 func TRXToSun(trxValue *big.Float) *big.Int {
-	base := big.NewInt(10)
-	exp := big.NewInt(6)
-	decimalsPow := new(big.Int).Exp(base, exp, nil)
-	decimalsBigFloat := big.NewFloat(0).SetInt(decimalsPow)
-
-	trxBigFloat := big.NewFloat(0).Set(trxValue)
+	v, _ := TRXToSunChecked(trxValue)
+	return v
+}
 
-	res := trxBigFloat.Mul(trxBigFloat, decimalsBigFloat)
-	resInt, _ := res.Int(nil)
-	return resInt
+func TRXToSunChecked(trxValue *big.Float) (*big.Int, error) {
+	return ToBlockchainAmountChecked(trxValue, TrxDecimals)
 }
 
 func ToHumanAmount(amount *big.Int, decimals uint8) *big.Float {
@@ -53,13 +57,22 @@ func ToHumanAmount(amount *big.Int, decimals uint8) *big.Float {
 	return res
 }
 
+// ToBlockchainAmount returns nil when amount is nil or infinite; use
+// ToBlockchainAmountChecked to get the reason.
 func ToBlockchainAmount(amount *big.Float, decimals uint8) *big.Int {
-	base := big.NewInt(10)
-	exp := big.NewInt(int64(decimals))
-	decimalsPow := new(big.Int).Exp(base, exp, nil)
-	amountBigFloat := big.NewFloat(0).Set(amount)
+	v, _ := ToBlockchainAmountChecked(amount, decimals)
+	return v
+}
 
-	res := amountBigFloat.Mul(amountBigFloat, big.NewFloat(0).SetInt(decimalsPow))
-	resInt, _ := res.Int(nil)
-	return resInt
+// ToBlockchainAmountChecked goes through the shortest decimal form of amount,
+// so values like 0.1 convert exactly instead of picking up binary rounding
+// error. Extra fractional digits are truncated. Infinite amounts are an error.
+func ToBlockchainAmountChecked(amount *big.Float, decimals uint8) (*big.Int, error) {
+	if amount == nil {
+		return nil, errors.New("nil amount")
+	}
+	if amount.IsInf() {
+		return nil, fmt.Errorf("amount %s is not finite", amount.Text('g', -1))
+	}
+	return parseDecimal(amount.Text('f', -1), decimals, true)
 }
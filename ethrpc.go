@@ -0,0 +1,453 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// maxLogBlockRange is the widest eth_getLogs range java-tron serves by
+// default (node.jsonrpc.maxBlockRange).
+const maxLogBlockRange = 5000
+
+// EthClient implements bind.ContractCaller, which is CodeAt plus
+// ethereum.ContractCaller.
+var (
+	_ ethereum.ContractCaller = (*EthClient)(nil)
+	_ ethereum.LogFilterer    = (*EthClient)(nil)
+	_ interface {
+		CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error)
+	} = (*EthClient)(nil)
+)
+
+type EthClientOption func(*EthClient)
+
+// WithPollInterval sets how often SubscribeFilterLogs polls for new blocks.
+func WithPollInterval(d time.Duration) EthClientOption {
+	return func(e *EthClient) { e.pollInterval = d }
+}
+
+// EthClient speaks the Ethereum-compatible JSON-RPC API that TRON nodes serve
+// at /jsonrpc. It satisfies go-ethereum's bind.ContractCaller and
+// ethereum.LogFilterer, so abigen bindings can read TRON contracts:
+//
+//	eth := c.NewEthClient()
+//	token, err := NewERC20Caller(usdt.EVM(), eth)
+//
+// Requests go through the client, sharing its headers, API key, retries and
+// interceptors. Addresses on the wire are 20-byte EVM addresses; methods
+// taking or returning TRON addresses convert them.
+type EthClient struct {
+	c            *Client
+	pollInterval time.Duration
+	id           atomic.Uint64
+}
+
+func (c *Client) NewEthClient(opts ...EthClientOption) *EthClient {
+	e := &EthClient{
+		c:            c,
+		pollInterval: newBlockGenerationTime,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+	if e.pollInterval <= 0 {
+		e.pollInterval = newBlockGenerationTime
+	}
+	return e
+}
+
+// RPCError is a JSON-RPC error response. It implements go-ethereum's
+// rpc.Error and rpc.DataError, so bindings can decode revert data.
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    any    `json:"data,omitempty"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("jsonrpc error %d: %s", e.Code, e.Message)
+}
+
+func (e *RPCError) ErrorCode() int {
+	return e.Code
+}
+
+func (e *RPCError) ErrorData() any {
+	return e.Data
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      uint64 `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+}
+
+func (e *EthClient) call(ctx context.Context, method string, out any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	req := rpcRequest{
+		JSONRPC: "2.0",
+		ID:      e.id.Add(1),
+		Method:  method,
+		Params:  params,
+	}
+
+	var resp rpcResponse
+	if err := e.c.callWithRetry(ctx, http.MethodPost, method, "jsonrpc", req, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if len(resp.Result) == 0 || string(resp.Result) == "null" {
+		return ethereum.NotFound
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
+
+// blockArg encodes a block number; nil means the latest block, which is the
+// only state java-tron serves calls against.
+func blockArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+func callArg(msg ethereum.CallMsg) map[string]any {
+	arg := map[string]any{
+		"from": msg.From,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
+
+func (e *EthClient) BlockNumber(ctx context.Context) (uint64, error) {
+	var out hexutil.Uint64
+	err := e.call(ctx, "eth_blockNumber", &out)
+	return uint64(out), err
+}
+
+// BalanceAt returns the TRX balance of account in sun.
+func (e *EthClient) BalanceAt(ctx context.Context, account Address, blockNumber *big.Int) (*big.Int, error) {
+	var out hexutil.Big
+	if err := e.call(ctx, "eth_getBalance", &out, account.EVM(), blockArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return (*big.Int)(&out), nil
+}
+
+func (e *EthClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	var out hexutil.Bytes
+	err := e.call(ctx, "eth_getCode", &out, contract, blockArg(blockNumber))
+	return out, err
+}
+
+func (e *EthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var out hexutil.Bytes
+	err := e.call(ctx, "eth_call", &out, callArg(msg), blockArg(blockNumber))
+	return out, err
+}
+
+// Call runs a constant call of data against contract at the latest block.
+func (e *EthClient) Call(ctx context.Context, from Address, contract Address, data []byte) ([]byte, error) {
+	to := contract.EVM()
+	return e.CallContract(ctx, ethereum.CallMsg{From: from.EVM(), To: &to, Data: data}, nil)
+}
+
+// EstimateGas returns the energy msg would use.
+func (e *EthClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var out hexutil.Uint64
+	err := e.call(ctx, "eth_estimateGas", &out, callArg(msg))
+	return uint64(out), err
+}
+
+// EthReceipt is a transaction receipt as returned by eth_getTransactionReceipt,
+// with addresses converted to TRON addresses.
+type EthReceipt struct {
+	TxID              string
+	BlockHash         common.Hash
+	BlockNumber       uint64
+	TransactionIndex  uint
+	From              Address
+	To                Address
+	ContractAddress   Address
+	GasUsed           uint64
+	CumulativeGasUsed uint64
+	EffectiveGasPrice *big.Int
+	Status            uint64
+	Logs              []types.Log
+}
+
+type ethReceiptJSON struct {
+	TransactionHash   common.Hash     `json:"transactionHash"`
+	BlockHash         common.Hash     `json:"blockHash"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	TransactionIndex  hexutil.Uint    `json:"transactionIndex"`
+	From              *common.Address `json:"from"`
+	To                *common.Address `json:"to"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	GasUsed           hexutil.Uint64  `json:"gasUsed"`
+	CumulativeGasUsed hexutil.Uint64  `json:"cumulativeGasUsed"`
+	EffectiveGasPrice *hexutil.Big    `json:"effectiveGasPrice"`
+	Status            hexutil.Uint64  `json:"status"`
+	Logs              []types.Log     `json:"logs"`
+}
+
+func optionalAddress(a *common.Address) Address {
+	if a == nil || *a == (common.Address{}) {
+		return Address{}
+	}
+	return AddressFromEVM(*a)
+}
+
+// TransactionReceipt returns the receipt of a mined transaction, or
+// ethereum.NotFound while it is pending.
+func (e *EthClient) TransactionReceipt(ctx context.Context, txID string) (*EthReceipt, error) {
+	txID = strings.TrimPrefix(strings.TrimPrefix(txID, "0x"), "0X")
+
+	var out ethReceiptJSON
+	if err := e.call(ctx, "eth_getTransactionReceipt", &out, "0x"+txID); err != nil {
+		return nil, err
+	}
+
+	r := &EthReceipt{
+		TxID:              strings.TrimPrefix(out.TransactionHash.Hex(), "0x"),
+		BlockHash:         out.BlockHash,
+		BlockNumber:       uint64(out.BlockNumber),
+		TransactionIndex:  uint(out.TransactionIndex),
+		From:              optionalAddress(out.From),
+		To:                optionalAddress(out.To),
+		ContractAddress:   optionalAddress(out.ContractAddress),
+		GasUsed:           uint64(out.GasUsed),
+		CumulativeGasUsed: uint64(out.CumulativeGasUsed),
+		Status:            uint64(out.Status),
+		Logs:              out.Logs,
+	}
+	if out.EffectiveGasPrice != nil {
+		r.EffectiveGasPrice = out.EffectiveGasPrice.ToInt()
+	}
+	return r, nil
+}
+
+func filterArg(q ethereum.FilterQuery) (map[string]any, error) {
+	arg := map[string]any{}
+	if len(q.Addresses) > 0 {
+		arg["address"] = q.Addresses
+	}
+	if len(q.Topics) > 0 {
+		arg["topics"] = q.Topics
+	}
+	if q.BlockHash != nil {
+		if q.FromBlock != nil || q.ToBlock != nil {
+			return nil, errors.New("cannot specify both BlockHash and FromBlock/ToBlock")
+		}
+		arg["blockHash"] = *q.BlockHash
+		return arg, nil
+	}
+	arg["fromBlock"] = blockArg(q.FromBlock)
+	arg["toBlock"] = blockArg(q.ToBlock)
+	return arg, nil
+}
+
+// Block tags as go-ethereum's rpc package encodes them in a *big.Int, e.g.
+// big.NewInt(int64(rpc.LatestBlockNumber)). Importing rpc would pull in its
+// websocket dependencies.
+const (
+	earliestBlockTag  = -5
+	safeBlockTag      = -4
+	finalizedBlockTag = -3
+	latestBlockTag    = -2
+	pendingBlockTag   = -1
+)
+
+// resolveBlock turns a block number or block tag into a height. Latest and
+// pending are the head block; finalized and safe are rejected, as the
+// JSON-RPC API has no way to ask for the solidified height.
+func (e *EthClient) resolveBlock(ctx context.Context, number *big.Int) (uint64, error) {
+	if number.IsUint64() {
+		return number.Uint64(), nil
+	}
+	if !number.IsInt64() {
+		return 0, fmt.Errorf("invalid block number %s", number)
+	}
+	switch number.Int64() {
+	case earliestBlockTag:
+		return 0, nil
+	case latestBlockTag, pendingBlockTag:
+		return e.BlockNumber(ctx)
+	case finalizedBlockTag, safeBlockTag:
+		return 0, errors.New("finalized and safe block tags are not supported")
+	default:
+		return 0, fmt.Errorf("invalid block number %s", number)
+	}
+}
+
+// FilterLogs runs eth_getLogs from q.FromBlock, which is required, to
+// q.ToBlock or the latest block. Ranges wider than maxLogBlockRange are
+// fetched in windows. Log addresses are EVM addresses; use AddressFromEVM for
+// the TRON form.
+func (e *EthClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if q.BlockHash != nil {
+		return e.getLogs(ctx, q)
+	}
+	if q.FromBlock == nil {
+		return nil, errors.New("FromBlock must be set; scanning from genesis is not supported")
+	}
+
+	from, err := e.resolveBlock(ctx, q.FromBlock)
+	if err != nil {
+		return nil, err
+	}
+	var to uint64
+	if q.ToBlock != nil {
+		to, err = e.resolveBlock(ctx, q.ToBlock)
+	} else {
+		to, err = e.BlockNumber(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var out []types.Log
+	for next := from; next <= to; {
+		end := min(to, next+maxLogBlockRange-1)
+		window := q
+		window.FromBlock = new(big.Int).SetUint64(next)
+		window.ToBlock = new(big.Int).SetUint64(end)
+
+		logs, err := e.getLogs(ctx, window)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, logs...)
+		next = end + 1
+	}
+	return out, nil
+}
+
+func (e *EthClient) getLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	arg, err := filterArg(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []types.Log
+	if err := e.call(ctx, "eth_getLogs", &out, arg); err != nil && !errors.Is(err, ethereum.NotFound) {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SubscribeFilterLogs polls eth_getLogs for logs matching q, since TRON nodes
+// do not push them. It starts at q.FromBlock, or at the next block when
+// unset, and ends after q.ToBlock when set.
+func (e *EthClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	if q.BlockHash != nil {
+		return nil, errors.New("cannot subscribe to logs of a single block")
+	}
+	if q.ToBlock != nil && !q.ToBlock.IsUint64() {
+		return nil, errors.New("ToBlock must be a block number")
+	}
+
+	var next uint64
+	if q.FromBlock != nil {
+		var err error
+		if next, err = e.resolveBlock(ctx, q.FromBlock); err != nil {
+			return nil, err
+		}
+	} else {
+		head, err := e.BlockNumber(ctx)
+		if err != nil {
+			return nil, err
+		}
+		next = head + 1
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ticker := time.NewTicker(e.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-quit:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+
+			head, err := e.BlockNumber(ctx)
+			if err != nil {
+				return err
+			}
+			if q.ToBlock != nil {
+				head = min(head, q.ToBlock.Uint64())
+			}
+
+			for next <= head {
+				to := min(head, next+maxLogBlockRange-1)
+				window := q
+				window.FromBlock = new(big.Int).SetUint64(next)
+				window.ToBlock = new(big.Int).SetUint64(to)
+
+				logs, err := e.FilterLogs(ctx, window)
+				if err != nil {
+					return err
+				}
+				for _, l := range logs {
+					select {
+					case ch <- l:
+					case <-quit:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+				next = to + 1
+			}
+
+			if q.ToBlock != nil && next > q.ToBlock.Uint64() {
+				return nil
+			}
+		}
+	}), nil
+}
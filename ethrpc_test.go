@@ -0,0 +1,105 @@
+package tron_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// Block tags as go-ethereum's rpc package defines them.
+const (
+	safeBlock      = -4
+	finalizedBlock = -3
+	latestBlock    = -2
+	pendingBlock   = -1
+)
+
+func TestFilterLogs(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 100_000_000)
+	addr := deploy(t, node, mockTRC1155(), map[common.Hash]common.Hash{
+		multiSlot(alice.Address, 1): common.BigToHash(big.NewInt(100)),
+	})
+	c := node.Client()
+	token := c.NewTRC1155(addr)
+	transfer := func() {
+		t.Helper()
+		tx, err := token.BuildSafeTransferFromTx(ctx, alice.Address, bob.Address, big.NewInt(1), big.NewInt(1), nil, feeLimit)
+		if err != nil {
+			t.Fatalf("build safeTransferFrom: %v", err)
+		}
+		send(t, c, tx, alice)
+	}
+
+	// Two transfers more than one eth_getLogs window apart, and one in the
+	// head block.
+	transfer()
+	first := node.BlockNumber()
+	node.ProduceBlocks(6000)
+	transfer()
+	transfer()
+	head := node.BlockNumber()
+
+	eth := c.NewEthClient()
+	q := ethereum.FilterQuery{
+		Addresses: []common.Address{addr.EVM()},
+		Topics:    [][]common.Hash{{common.BytesToHash(transferSingleTopic)}},
+	}
+
+	t.Run("range", func(t *testing.T) {
+		q := q
+		q.FromBlock = big.NewInt(0)
+		logs, err := eth.FilterLogs(ctx, q)
+		if err != nil {
+			t.Fatalf("filter logs: %v", err)
+		}
+		if len(logs) != 3 || logs[0].BlockNumber != uint64(first) || logs[2].BlockNumber != uint64(head) {
+			t.Fatalf("got %d logs, want 3 from block %d to %d", len(logs), first, head)
+		}
+	})
+
+	t.Run("latest", func(t *testing.T) {
+		q := q
+		q.FromBlock = big.NewInt(latestBlock)
+		q.ToBlock = big.NewInt(pendingBlock)
+		logs, err := eth.FilterLogs(ctx, q)
+		if err != nil {
+			t.Fatalf("filter logs: %v", err)
+		}
+		if len(logs) != 1 || logs[0].BlockNumber != uint64(head) {
+			t.Fatalf("got %d logs, want the one in head block %d", len(logs), head)
+		}
+	})
+
+	t.Run("topics", func(t *testing.T) {
+		q := q
+		q.FromBlock = big.NewInt(first)
+		q.ToBlock = big.NewInt(first)
+		q.Topics = [][]common.Hash{nil, nil, nil, {common.BytesToHash(alice.Address.EVM().Bytes())}}
+		if logs, err := eth.FilterLogs(ctx, q); err != nil || len(logs) != 0 {
+			t.Fatalf("logs to alice = %v, %v; want none", logs, err)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		for name, from := range map[string]*big.Int{
+			"unset":     nil,
+			"finalized": big.NewInt(finalizedBlock),
+			"safe":      big.NewInt(safeBlock),
+		} {
+			q := q
+			q.FromBlock = from
+			if _, err := eth.FilterLogs(ctx, q); err == nil {
+				t.Fatalf("FromBlock %s: want an error", name)
+			}
+		}
+	})
+}
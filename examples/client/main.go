@@ -37,8 +37,8 @@ func main() {
 
 	log.Printf("nowBlock: %s", nowBlock)
 
-	trc20 := client.NewTRC20(trc20Address)
-	tx, err := trc20.BuildTransferTx(context.Background(), fromAddress, toAddress, big.NewInt(1000000), 100000000)
+	trc20 := client.NewTRC20(tron.MustParseAddress(trc20Address))
+	tx, err := trc20.BuildTransferTx(context.Background(), tron.MustParseAddress(fromAddress), tron.MustParseAddress(toAddress), big.NewInt(1000000), 100000000)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -11,14 +11,17 @@ import (
 )
 
 const (
-	multicallAddress = "TPP1ToFfmVXVTeWfJHAjmXsWnUGV8EkmnW"
-	rpc              = "https://nile.trongrid.io"
-	tokenAddress     = "TRPXG8YEMEaYE9dRs6fXvofFTiyMFE2mEg"
+	rpc = "https://nile.trongrid.io"
 )
 
-var addresses = []string{
-	"TFbBApWL6TfyBhB8Tr322NeSUPePjnH4qe",
-	"TZJ32TTQgjqcYWQf626xTWaZUT9iKLXxtS",
+var (
+	multicallAddress = tron.MustParseAddress("TPP1ToFfmVXVTeWfJHAjmXsWnUGV8EkmnW")
+	tokenAddress     = tron.MustParseAddress("TRPXG8YEMEaYE9dRs6fXvofFTiyMFE2mEg")
+)
+
+var addresses = []tron.Address{
+	tron.MustParseAddress("TFbBApWL6TfyBhB8Tr322NeSUPePjnH4qe"),
+	tron.MustParseAddress("TZJ32TTQgjqcYWQf626xTWaZUT9iKLXxtS"),
 }
 
 func main() {
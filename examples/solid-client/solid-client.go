@@ -18,16 +18,16 @@ const (
 
 func main() {
 	client := tron.NewSolid(rpc)
-	trc20 := client.NewTRC20(trc20Address)
+	trc20 := client.NewTRC20(tron.MustParseAddress(trc20Address))
 
-	balance, err := trc20.BalanceOf(context.Background(), fromAddress)
+	balance, err := trc20.BalanceOf(context.Background(), tron.MustParseAddress(fromAddress))
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	log.Printf("balance: %s", balance)
 
-	tx, err := trc20.BuildTransferTx(context.Background(), fromAddress, toAddress, big.NewInt(1000000), 100000000)
+	tx, err := trc20.BuildTransferTx(context.Background(), tron.MustParseAddress(fromAddress), tron.MustParseAddress(toAddress), big.NewInt(1000000), 100000000)
 	if err != nil {
 		log.Fatal(err)
 	}
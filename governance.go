@@ -0,0 +1,251 @@
+package tron
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type Witness struct {
+	Address        Address `json:"address"`
+	VoteCount      int64   `json:"voteCount"`
+	URL            string  `json:"url"`
+	TotalProduced  int64   `json:"totalProduced"`
+	TotalMissed    int64   `json:"totalMissed"`
+	LatestBlockNum int64   `json:"latestBlockNum"`
+	LatestSlotNum  int64   `json:"latestSlotNum"`
+	IsJobs         bool    `json:"isJobs"`
+}
+
+type witnessListResp struct {
+	Witnesses []Witness `json:"witnesses"`
+}
+
+func (c *Client) ListWitnesses(ctx context.Context) ([]Witness, error) {
+	var out witnessListResp
+	if err := c.Call(ctx, "listwitnesses", VisibleReq{Visible: c.visible}, &out); err != nil {
+		return nil, err
+	}
+	return out.Witnesses, nil
+}
+
+type AddressReq struct {
+	Address Address `json:"address"`
+	Visible bool    `json:"visible,omitempty"`
+}
+
+type brokerageResp struct {
+	Brokerage int64 `json:"brokerage"`
+}
+
+// GetBrokerage returns the percentage of rewards the witness keeps before
+// sharing the rest with its voters.
+func (c *Client) GetBrokerage(ctx context.Context, witness Address) (int64, error) {
+	var out brokerageResp
+	err := c.Call(ctx, "getbrokerage", AddressReq{Address: witness, Visible: c.visible}, &out)
+	return out.Brokerage, err
+}
+
+type rewardResp struct {
+	Reward int64 `json:"reward"`
+}
+
+// GetReward returns the unclaimed voting reward of address in sun.
+func (c *Client) GetReward(ctx context.Context, address Address) (int64, error) {
+	var out rewardResp
+	err := c.Call(ctx, "getreward", AddressReq{Address: address, Visible: c.visible}, &out)
+	return out.Reward, err
+}
+
+type numResp struct {
+	Num int64 `json:"num"`
+}
+
+func (c *Client) GetNextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	var out numResp
+	if err := c.Call(ctx, "getnextmaintenancetime", nil, &out); err != nil {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(out.Num), nil
+}
+
+type ChainParameter struct {
+	Key   string `json:"key"`
+	Value int64  `json:"value"`
+}
+
+type chainParametersResp struct {
+	ChainParameter []ChainParameter `json:"chainParameter"`
+}
+
+func (c *Client) GetChainParameters(ctx context.Context) ([]ChainParameter, error) {
+	var out chainParametersResp
+	if err := c.Call(ctx, "getchainparameters", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.ChainParameter, nil
+}
+
+func (c *Client) ListProposals(ctx context.Context) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "listproposals", VisibleReq{Visible: c.visible}, &out)
+	return out, err
+}
+
+type GetProposalByIDReq struct {
+	ID      int64 `json:"id"`
+	Visible bool  `json:"visible,omitempty"`
+}
+
+func (c *Client) GetProposalByID(ctx context.Context, id int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getproposalbyid", GetProposalByIDReq{ID: id, Visible: c.visible}, &out)
+	return out, err
+}
+
+type PaginatedReq struct {
+	Offset  int64 `json:"offset"`
+	Limit   int64 `json:"limit"`
+	Visible bool  `json:"visible,omitempty"`
+}
+
+func (c *Client) GetPaginatedProposalList(ctx context.Context, offset int64, limit int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getpaginatedproposallist", PaginatedReq{
+		Offset:  offset,
+		Limit:   limit,
+		Visible: c.visible,
+	}, &out)
+	return out, err
+}
+
+type Vote struct {
+	VoteAddress Address `json:"vote_address"`
+	VoteCount   int64   `json:"vote_count"`
+}
+
+type VoteWitnessAccountReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	Votes        []Vote  `json:"votes"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+// VoteWitnessAccount replaces all of the owner's votes with req.Votes.
+func (c *Client) VoteWitnessAccount(ctx context.Context, req VoteWitnessAccountReq) (Raw, error) {
+	if len(req.Votes) == 0 {
+		return nil, errors.New("at least one vote is required")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "votewitnessaccount", req, &out)
+	return out, err
+}
+
+// WithdrawBalance claims the owner's voting and block rewards.
+func (c *Client) WithdrawBalance(ctx context.Context, owner Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "withdrawbalance", OwnerReq{OwnerAddress: owner, Visible: c.visible}, &out)
+	return out, err
+}
+
+type CreateWitnessReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	URL          string  `json:"url"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) CreateWitness(ctx context.Context, req CreateWitnessReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "createwitness", req, &out)
+	return out, err
+}
+
+type UpdateWitnessReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	UpdateURL    string  `json:"update_url"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) UpdateWitness(ctx context.Context, req UpdateWitnessReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "updatewitness", req, &out)
+	return out, err
+}
+
+type UpdateBrokerageReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	Brokerage    int64   `json:"brokerage"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) UpdateBrokerage(ctx context.Context, req UpdateBrokerageReq) (Raw, error) {
+	if req.Brokerage < 0 || req.Brokerage > 100 {
+		return nil, errors.New("brokerage must be between 0 and 100")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "updatebrokerage", req, &out)
+	return out, err
+}
+
+type ProposalParameter struct {
+	Key   int64 `json:"key"`
+	Value int64 `json:"value"`
+}
+
+type ProposalCreateReq struct {
+	OwnerAddress Address             `json:"owner_address"`
+	Parameters   []ProposalParameter `json:"parameters"`
+	PermissionID int                 `json:"Permission_id,omitempty"`
+	Visible      bool                `json:"visible,omitempty"`
+}
+
+// ProposalCreate builds a proposal to change chain parameters. Keys are the
+// numeric parameter ids, e.g. 0 for the maintenance interval.
+func (c *Client) ProposalCreate(ctx context.Context, req ProposalCreateReq) (Raw, error) {
+	if len(req.Parameters) == 0 {
+		return nil, errors.New("at least one parameter is required")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "proposalcreate", req, &out)
+	return out, err
+}
+
+type ProposalApproveReq struct {
+	OwnerAddress  Address `json:"owner_address"`
+	ProposalID    int64   `json:"proposal_id"`
+	IsAddApproval bool    `json:"is_add_approval"`
+	PermissionID  int     `json:"Permission_id,omitempty"`
+	Visible       bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) ProposalApprove(ctx context.Context, req ProposalApproveReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "proposalapprove", req, &out)
+	return out, err
+}
+
+type ProposalDeleteReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	ProposalID   int64   `json:"proposal_id"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) ProposalDelete(ctx context.Context, req ProposalDeleteReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "proposaldelete", req, &out)
+	return out, err
+}
@@ -0,0 +1,116 @@
+package tron_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// transportFunc adapts a function to http.RoundTripper.
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+	alice := trontest.NewKey("alice").Address
+	node.Fund(alice, 1_000_000)
+
+	// The first request fails with 503; every request must carry the header
+	// the interceptor adds.
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+	)
+	transport := transportFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests = append(requests, r)
+		n := len(requests)
+		mu.Unlock()
+		if n == 1 {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: r}, nil
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	var infos []tron.CallInfo
+	record := func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			info, ok := tron.CallInfoFromContext(ctx)
+			if !ok {
+				t.Error("no CallInfo in the interceptor context")
+			}
+			infos = append(infos, info)
+			return next(tron.ContextWithHeader(ctx, "X-Request-Tag", "tagged"), path, req, out)
+		}
+	}
+
+	c := tron.New(node.URL(),
+		tron.WithHTTPClient(&http.Client{Transport: transport}),
+		tron.WithInterceptor(record),
+		tron.WithRetry(1, 0),
+		tron.WithSolid(true),
+	)
+	if _, err := c.GetAccount(ctx, alice); err != nil {
+		t.Fatalf("get account: %v", err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("interceptor ran %d times, want once per attempt", len(infos))
+	}
+	for i, info := range infos {
+		want := tron.CallInfo{
+			Method:     "getaccount",
+			Path:       "walletsolidity/getaccount",
+			HTTPMethod: http.MethodPost,
+			Endpoint:   node.URL(),
+			Solid:      true,
+			Attempt:    i,
+		}
+		if info != want {
+			t.Fatalf("CallInfo[%d] = %+v, want %+v", i, info, want)
+		}
+	}
+	for _, r := range requests {
+		if r.Method != http.MethodPost || r.Header.Get("X-Request-Tag") != "tagged" {
+			t.Fatalf("request %s %s, X-Request-Tag %q", r.Method, r.URL, r.Header.Get("X-Request-Tag"))
+		}
+	}
+}
+
+func TestInterceptorTronGrid(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		w.Write([]byte(`{"success":true,"data":[]}`))
+	}))
+	defer srv.Close()
+
+	var info tron.CallInfo
+	record := func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			info, _ = tron.CallInfoFromContext(ctx)
+			return next(ctx, path, req, out)
+		}
+	}
+	grid := tron.New(srv.URL, tron.WithInterceptor(record)).NewTronGrid()
+	if _, err := grid.TransactionEvents(context.Background(), "abc", true); err != nil {
+		t.Fatalf("transaction events: %v", err)
+	}
+
+	if info.HTTPMethod != http.MethodGet || info.Method != "v1/transactions/events" {
+		t.Fatalf("CallInfo = %+v, want a GET of v1/transactions/events", info)
+	}
+	if got.Method != http.MethodGet || got.URL.Path != "/v1/transactions/abc/events" || got.URL.Query().Get("only_confirmed") != "true" {
+		t.Fatalf("request = %s %s", got.Method, got.URL)
+	}
+}
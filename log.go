@@ -0,0 +1,67 @@
+package tron
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+)
+
+const maxLoggedBody = 512
+
+// WithLogger enables logging of node calls: every attempt and response at
+// debug, retries at warn and calls that finally fail at error. Logged
+// headers and bodies are redacted as described at logBody; errors returned
+// to the caller are not.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) { c.logger = logger }
+}
+
+var sensitiveHeaders = []string{"TRON-PRO-API-KEY", "X-API-Key", "Authorization"}
+
+var (
+	signaturePattern = regexp.MustCompile(`"signature"\s*:\s*\[[^\]]*\]`)
+	// Secret string fields: private keys and passphrases taken or returned by
+	// gettransactionsign, easytransferbyprivate, createaddress and
+	// generateaddress, and API keys or tokens echoed by proxies.
+	secretFieldPattern = regexp.MustCompile(`(?i)"(private_?key|pass_?phrase|password|api_?key|secret|access_?token)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+)
+
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range sensitiveHeaders {
+		if out.Get(k) != "" {
+			out.Set(k, "REDACTED")
+		}
+	}
+	return out
+}
+
+// logBody truncates b for logging after redacting transaction signatures and
+// the string values of the JSON fields privateKey, passPhrase, password,
+// apiKey, secret and accessToken, matched case-insensitively and with or
+// without an underscore. Headers are redacted by redactHeader: the TronGrid
+// API key, X-API-Key and Authorization. Secrets elsewhere, e.g. in URLs or under other
+// field names, are logged as is.
+func logBody(b []byte) string {
+	b = signaturePattern.ReplaceAll(b, []byte(`"signature":["REDACTED"]`))
+	b = secretFieldPattern.ReplaceAll(b, []byte(`"$1":"REDACTED"`))
+	if len(b) > maxLoggedBody {
+		return string(b[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(b)
+}
+
+// errorAttrs describes err for logging. Node responses are logged through
+// logBody rather than as part of the error text.
+func errorAttrs(err error) slog.Attr {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return slog.Group("error", "status", apiErr.StatusCode, "body", logBody([]byte(apiErr.Body)))
+	}
+	var decodeErr *DecodeError
+	if errors.As(err, &decodeErr) {
+		return slog.Group("error", "status", decodeErr.StatusCode, "message", decodeErr.Error(), "body", logBody([]byte(decodeErr.Body)))
+	}
+	return slog.Any("error", err)
+}
@@ -0,0 +1,82 @@
+package tron_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestLoggerRedacts(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 1_000_000)
+
+	const leaked = `{"Error":"bad request","privateKey":"c0ffee","api_key":"k3y","PassPhrase":"p4ss"}`
+	transport := transportFunc(func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "/getaccount") {
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: io.NopCloser(strings.NewReader(leaked)), Request: r}, nil
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	var logs bytes.Buffer
+	c := tron.New(node.URL(),
+		tron.WithHTTPClient(&http.Client{Transport: transport}),
+		tron.WithLogger(slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		tron.WithTronGridAPIKey("grid-secret"),
+	)
+
+	tx, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, alice.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.BroadcastTransaction(ctx, signed)
+	if err != nil || !resp.Result {
+		t.Fatalf("broadcast = %+v, %v", resp, err)
+	}
+
+	_, err = c.GetAccount(ctx, alice.Address)
+	var apiErr *tron.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("get account error = %v, want an APIError", err)
+	}
+	// The caller gets the body as the node sent it.
+	if !strings.Contains(err.Error(), leaked) {
+		t.Fatalf("error = %q, want the raw body", err)
+	}
+
+	// A short body, so the signature is not cut off by truncation.
+	var out tron.BroadcastResp
+	c.Call(ctx, "broadcasttransaction", map[string]any{"signature": []string{"5eed5eed"}}, &out)
+
+	var stx tron.TronTx
+	if err := json.Unmarshal(signed, &stx); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{stx.Signature[0], "5eed5eed", "c0ffee", "k3y", "p4ss", "grid-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Fatalf("logs contain %q:\n%s", secret, logs.String())
+		}
+	}
+	for _, want := range []string{`\"signature\":[\"REDACTED\"]`, `\"privateKey\":\"REDACTED\"`, `\"PassPhrase\":\"REDACTED\"`, "Tron-Pro-Api-Key:[REDACTED]"} {
+		if !strings.Contains(logs.String(), want) {
+			t.Fatalf("logs lack %s:\n%s", want, logs.String())
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package tron
+
+import (
+	"context"
+	"errors"
+)
+
+// Token ids in the exchange and market APIs are TRC10 ids, with "_" standing
+// for TRX.
+const TRXTokenID = "_"
+
+type GetExchangeByIDReq struct {
+	ID      int64 `json:"id"`
+	Visible bool  `json:"visible,omitempty"`
+}
+
+func (c *Client) GetExchangeByID(ctx context.Context, id int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getexchangebyid", GetExchangeByIDReq{ID: id, Visible: c.visible}, &out)
+	return out, err
+}
+
+func (c *Client) ListExchanges(ctx context.Context) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "listexchanges", VisibleReq{Visible: c.visible}, &out)
+	return out, err
+}
+
+func (c *Client) GetPaginatedExchangeList(ctx context.Context, offset int64, limit int64) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getpaginatedexchangelist", PaginatedReq{
+		Offset:  offset,
+		Limit:   limit,
+		Visible: c.visible,
+	}, &out)
+	return out, err
+}
+
+type ExchangeTransactionReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	ExchangeID   int64   `json:"exchange_id"`
+	TokenID      string  `json:"token_id"`
+	Quant        int64   `json:"quant"`
+	// Expected is the minimum amount of the other token to receive.
+	Expected     int64 `json:"expected"`
+	PermissionID int   `json:"Permission_id,omitempty"`
+	Visible      bool  `json:"visible,omitempty"`
+}
+
+func (c *Client) ExchangeTransaction(ctx context.Context, req ExchangeTransactionReq) (Raw, error) {
+	if req.Quant <= 0 {
+		return nil, errors.New("quant must be positive")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "exchangetransaction", req, &out)
+	return out, err
+}
+
+type MarketSellAssetReq struct {
+	OwnerAddress      Address `json:"owner_address"`
+	SellTokenID       string  `json:"sell_token_id"`
+	SellTokenQuantity int64   `json:"sell_token_quantity"`
+	BuyTokenID        string  `json:"buy_token_id"`
+	BuyTokenQuantity  int64   `json:"buy_token_quantity"`
+	PermissionID      int     `json:"Permission_id,omitempty"`
+	Visible           bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) MarketSellAsset(ctx context.Context, req MarketSellAssetReq) (Raw, error) {
+	if req.SellTokenQuantity <= 0 || req.BuyTokenQuantity <= 0 {
+		return nil, errors.New("token quantities must be positive")
+	}
+	if req.SellTokenID == req.BuyTokenID {
+		return nil, errors.New("sell and buy tokens must differ")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "marketsellasset", req, &out)
+	return out, err
+}
+
+type MarketCancelOrderReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	OrderID      string  `json:"order_id"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) MarketCancelOrder(ctx context.Context, req MarketCancelOrderReq) (Raw, error) {
+	req.Visible = c.visible
+	var out Raw
+	err := c.Call(ctx, "marketcancelorder", req, &out)
+	return out, err
+}
+
+func (c *Client) GetMarketOrderByAccount(ctx context.Context, address Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getmarketorderbyaccount", AddressValueReq{Value: address, Visible: c.visible}, &out)
+	return out, err
+}
+
+type GetMarketOrderByIDReq struct {
+	Value   string `json:"value"`
+	Visible bool   `json:"visible,omitempty"`
+}
+
+func (c *Client) GetMarketOrderByID(ctx context.Context, orderID string) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getmarketorderbyid", GetMarketOrderByIDReq{Value: orderID, Visible: c.visible}, &out)
+	return out, err
+}
+
+type MarketPairReq struct {
+	SellTokenID string `json:"sell_token_id"`
+	BuyTokenID  string `json:"buy_token_id"`
+	Visible     bool   `json:"visible,omitempty"`
+}
+
+func (c *Client) GetMarketPriceByPair(ctx context.Context, sellTokenID string, buyTokenID string) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getmarketpricebypair", MarketPairReq{
+		SellTokenID: sellTokenID,
+		BuyTokenID:  buyTokenID,
+		Visible:     c.visible,
+	}, &out)
+	return out, err
+}
+
+func (c *Client) GetMarketOrderListByPair(ctx context.Context, sellTokenID string, buyTokenID string) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getmarketorderlistbypair", MarketPairReq{
+		SellTokenID: sellTokenID,
+		BuyTokenID:  buyTokenID,
+		Visible:     c.visible,
+	}, &out)
+	return out, err
+}
+
+func (c *Client) GetMarketPairList(ctx context.Context) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getmarketpairlist", VisibleReq{Visible: c.visible}, &out)
+	return out, err
+}
@@ -0,0 +1,55 @@
+package tron
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const tronMessagePrefix = "\x19TRON Signed Message:\n"
+
+// HashMessageV2 returns the TIP-191 digest TronWeb's signMessageV2 signs.
+func HashMessageV2(message []byte) []byte {
+	prefix := tronMessagePrefix + strconv.Itoa(len(message))
+	return crypto.Keccak256([]byte(prefix), message)
+}
+
+// SignMessageV2 signs message like TronWeb's signMessageV2 and returns the
+// 0x-prefixed signature with a 27/28 recovery id.
+func SignMessageV2(message []byte, privateKeyHex string) (string, error) {
+	priv, err := parsePrivateKeyHex(privateKeyHex)
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := crypto.Sign(HashMessageV2(message), priv)
+	if err != nil {
+		return "", fmt.Errorf("sign failed: %w", err)
+	}
+	sig[64] += 27
+
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+func RecoverMessageV2(message []byte, signature string) (string, error) {
+	signer, err := recoverSigner(HashMessageV2(message), signature)
+	if err != nil {
+		return "", err
+	}
+	return signer.String(), nil
+}
+
+func VerifyMessageV2(message []byte, signature string, address string) (bool, error) {
+	want, err := ParseAddress(address)
+	if err != nil {
+		return false, fmt.Errorf("invalid address: %w", err)
+	}
+
+	got, err := recoverSigner(HashMessageV2(message), signature)
+	if err != nil {
+		return false, err
+	}
+	return got == want, nil
+}
@@ -0,0 +1,49 @@
+package tron_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+// cowKey is keccak256("cow"), the signer of the EIP-712 reference example.
+const (
+	cowKey     = "c85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4"
+	cowAddress = "TUg28KYvCXWW81EqMUeZvCZmZw2BChk1HQ"
+)
+
+func TestHashMessageV2(t *testing.T) {
+	got := hex.EncodeToString(tron.HashMessageV2([]byte("Hello World")))
+	want := "a8383a95afcc961b6c36437aff5c8e38a3e35a0ab36ec8630c42fd11f455eac5"
+	if got != want {
+		t.Fatalf("HashMessageV2 = %s, want %s", got, want)
+	}
+}
+
+func TestSignMessageV2(t *testing.T) {
+	msg := []byte("Hello World")
+	sig, err := tron.SignMessageV2(msg, cowKey)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if len(sig) != 2+65*2 || (sig[len(sig)-2:] != "1b" && sig[len(sig)-2:] != "1c") {
+		t.Fatalf("signature %s is not 0x-prefixed with a 27/28 recovery id", sig)
+	}
+
+	signer, err := tron.RecoverMessageV2(msg, sig)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if signer != cowAddress {
+		t.Fatalf("signer = %s, want %s", signer, cowAddress)
+	}
+
+	ok, err := tron.VerifyMessageV2([]byte("Hello World!"), sig, cowAddress)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if ok {
+		t.Fatal("signature verified for a different message")
+	}
+}
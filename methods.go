@@ -0,0 +1,197 @@
+package tron
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// MethodInfo describes a node HTTP API method.
+type MethodInfo struct {
+	Name string
+	// Solidity methods are also served under /walletsolidity and are routed
+	// there by a solid client.
+	Solidity bool
+	// Idempotent methods are read-only queries: repeating one has no effect
+	// on the chain, so concurrent identical calls may share a request and
+	// their responses may be cached. Transaction builders are not idempotent
+	// since every call returns a new transaction.
+	Idempotent bool
+	// Broadcast methods submit a signed transaction to the network. They are
+	// never retried, as a failed attempt may still have been accepted.
+	Broadcast bool
+}
+
+type methodKind int
+
+const (
+	methodQuery      methodKind = iota // read-only, full node only
+	methodSolidQuery                   // read-only, also on the solidity API
+	methodBuilder                      // builds an unsigned transaction
+	methodBroadcast                    // submits a signed transaction
+)
+
+var methodKinds = map[string]methodKind{
+	// Accounts
+	"getaccount":              methodSolidQuery,
+	"getaccountbyid":          methodSolidQuery,
+	"getaccountbalance":       methodQuery,
+	"getaccountnet":           methodQuery,
+	"getaccountresource":      methodQuery,
+	"validateaddress":         methodQuery,
+	"getapprovedlist":         methodQuery,
+	"getsignweight":           methodQuery,
+	"createaccount":           methodBuilder,
+	"updateaccount":           methodBuilder,
+	"setaccountid":            methodBuilder,
+	"accountpermissionupdate": methodBuilder,
+
+	// Blocks
+	"getnowblock":         methodSolidQuery,
+	"getblock":            methodSolidQuery,
+	"getblockbynum":       methodSolidQuery,
+	"getblockbyid":        methodSolidQuery,
+	"getblockbylimitnext": methodSolidQuery,
+	"getblockbylatestnum": methodSolidQuery,
+	"getblockbalance":     methodQuery,
+
+	// Transactions
+	"gettransactionbyid":            methodSolidQuery,
+	"gettransactioninfobyid":        methodSolidQuery,
+	"gettransactioninfobyblocknum":  methodSolidQuery,
+	"gettransactioncountbyblocknum": methodSolidQuery,
+	"gettransactionfrompending":     methodQuery,
+	"gettransactionlistfrompending": methodQuery,
+	"getpendingsize":                methodQuery,
+	"createtransaction":             methodBuilder,
+	"broadcasttransaction":          methodBroadcast,
+	"broadcasthex":                  methodBroadcast,
+
+	// Contracts
+	"triggerconstantcontract": methodSolidQuery,
+	"estimateenergy":          methodSolidQuery,
+	"getcontract":             methodQuery,
+	"getcontractinfo":         methodQuery,
+	"triggersmartcontract":    methodBuilder,
+	"deploycontract":          methodBuilder,
+	"updatesetting":           methodBuilder,
+	"updateenergylimit":       methodBuilder,
+	"clearabi":                methodBuilder,
+
+	// Resources
+	"getdelegatedresource":               methodSolidQuery,
+	"getdelegatedresourceaccountindex":   methodSolidQuery,
+	"getdelegatedresourcev2":             methodSolidQuery,
+	"getdelegatedresourceaccountindexv2": methodSolidQuery,
+	"getcandelegatedmaxsize":             methodSolidQuery,
+	"getavailableunfreezecount":          methodSolidQuery,
+	"getcanwithdrawunfreezeamount":       methodSolidQuery,
+	"getenergyprices":                    methodSolidQuery,
+	"getbandwidthprices":                 methodSolidQuery,
+	"getburntrx":                         methodSolidQuery,
+	"freezebalance":                      methodBuilder,
+	"unfreezebalance":                    methodBuilder,
+	"freezebalancev2":                    methodBuilder,
+	"unfreezebalancev2":                  methodBuilder,
+	"cancelallunfreezev2":                methodBuilder,
+	"withdrawexpireunfreeze":             methodBuilder,
+	"delegateresource":                   methodBuilder,
+	"undelegateresource":                 methodBuilder,
+
+	// Governance
+	"listwitnesses":            methodSolidQuery,
+	"getbrokerage":             methodSolidQuery,
+	"getreward":                methodSolidQuery,
+	"getnextmaintenancetime":   methodQuery,
+	"getchainparameters":       methodQuery,
+	"listproposals":            methodQuery,
+	"getproposalbyid":          methodQuery,
+	"getpaginatedproposallist": methodQuery,
+	"createwitness":            methodBuilder,
+	"updatewitness":            methodBuilder,
+	"votewitnessaccount":       methodBuilder,
+	"withdrawbalance":          methodBuilder,
+	"updatebrokerage":          methodBuilder,
+	"proposalcreate":           methodBuilder,
+	"proposalapprove":          methodBuilder,
+	"proposaldelete":           methodBuilder,
+
+	// Assets (TRC10)
+	"getassetissuebyid":          methodSolidQuery,
+	"getassetissuebyname":        methodSolidQuery,
+	"getassetissuelist":          methodSolidQuery,
+	"getassetissuelistbyname":    methodSolidQuery,
+	"getpaginatedassetissuelist": methodSolidQuery,
+	"getassetissuebyaccount":     methodQuery,
+	"createassetissue":           methodBuilder,
+	"updateasset":                methodBuilder,
+	"participateassetissue":      methodBuilder,
+	"unfreezeasset":              methodBuilder,
+	"transferasset":              methodBuilder,
+
+	// Exchanges and market
+	"getexchangebyid":          methodSolidQuery,
+	"listexchanges":            methodSolidQuery,
+	"getpaginatedexchangelist": methodQuery,
+	"getmarketorderbyaccount":  methodSolidQuery,
+	"getmarketorderbyid":       methodSolidQuery,
+	"getmarketpricebypair":     methodSolidQuery,
+	"getmarketorderlistbypair": methodSolidQuery,
+	"getmarketpairlist":        methodSolidQuery,
+	"exchangecreate":           methodBuilder,
+	"exchangeinject":           methodBuilder,
+	"exchangewithdraw":         methodBuilder,
+	"exchangetransaction":      methodBuilder,
+	"marketsellasset":          methodBuilder,
+	"marketcancelorder":        methodBuilder,
+
+	// Node
+	"getnodeinfo": methodSolidQuery,
+	"listnodes":   methodQuery,
+}
+
+// LookupMethod returns the metadata of a registered method.
+func LookupMethod(name string) (MethodInfo, bool) {
+	kind, ok := methodKinds[name]
+	if !ok {
+		return MethodInfo{}, false
+	}
+	return MethodInfo{
+		Name:       name,
+		Solidity:   kind == methodSolidQuery,
+		Idempotent: kind == methodQuery || kind == methodSolidQuery,
+		Broadcast:  kind == methodBroadcast,
+	}, true
+}
+
+// Methods returns the metadata of every registered method, sorted by name.
+func Methods() []MethodInfo {
+	names := slices.Sorted(maps.Keys(methodKinds))
+	out := make([]MethodInfo, 0, len(names))
+	for _, name := range names {
+		m, _ := LookupMethod(name)
+		out = append(out, m)
+	}
+	return out
+}
+
+const rawMethod = "raw"
+
+// CallRaw posts req to a path that is not in the registry, e.g.
+// "wallet/getpaginatednowwitnesslist" or "walletpbft/getnowblock", and returns
+// the raw response. The call is retried and intercepted like any other but
+// never cached. Interceptors see it as method "raw" so metric labels stay
+// bounded.
+func (c *Client) CallRaw(ctx context.Context, methodPath string, req any) (Raw, error) {
+	methodPath = strings.Trim(methodPath, "/")
+	if methodPath == "" {
+		return nil, errors.New("path must not be empty")
+	}
+
+	var out Raw
+	err := c.callWithRetry(ctx, http.MethodPost, rawMethod, methodPath, req, &out)
+	return out, err
+}
@@ -2,8 +2,6 @@ package tron
 
 import (
 	"context"
-	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"strings"
@@ -15,20 +13,58 @@ import (
 const multicallABIJSON = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall.Call[]","name":"calls","type":"tuple[]"}],"name":"aggregate","outputs":[{"internalType":"uint256","name":"blockNumber","type":"uint256"},{"internalType":"bytes[]","name":"returnData","type":"bytes[]"}],"stateMutability":"nonpayable","type":"function"}]`
 const erc20BalanceOfABIJSON = `[{"inputs":[{"internalType":"address","name":"account","type":"address"}],"name":"balanceOf","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
 
+const (
+	defaultMulticallChunkSize   = 200
+	defaultMulticallConcurrency = 4
+)
+
 type Multicall struct {
-	multicallAddress string
+	multicallAddress Address
 	c                *Client
+
+	chunkSize   int
+	concurrency int
+}
+
+type MulticallOption func(*Multicall)
+
+// WithChunkSize caps the number of calls sent in one triggerconstantcontract.
+func WithChunkSize(n int) MulticallOption {
+	return func(m *Multicall) { m.chunkSize = n }
 }
 
-func (c *Client) NewMulticall(multicallAddress string) *Multicall {
-	return &Multicall{c: c, multicallAddress: multicallAddress}
+// WithConcurrency caps the number of chunks in flight at once.
+func WithConcurrency(n int) MulticallOption {
+	return func(m *Multicall) { m.concurrency = n }
+}
+
+func (c *Client) NewMulticall(multicallAddress Address, opts ...MulticallOption) *Multicall {
+	m := &Multicall{
+		c:                c,
+		multicallAddress: multicallAddress,
+		chunkSize:        defaultMulticallChunkSize,
+		concurrency:      defaultMulticallConcurrency,
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+	if m.chunkSize < 1 {
+		m.chunkSize = 1
+	}
+	if m.concurrency < 1 {
+		m.concurrency = 1
+	}
+	return m
 }
 
 type MulticallCall struct {
-	Target   string
+	Target   Address
 	CallData []byte
 }
 
+var multicallABI = mustParseABI(multicallABIJSON)
+
 func (c *Multicall) AggregateMulticall(
 	ctx context.Context,
 	calls []MulticallCall,
@@ -37,86 +73,68 @@ func (c *Multicall) AggregateMulticall(
 		return nil, nil
 	}
 
-	mcABI, err := abi.JSON(strings.NewReader(multicallABIJSON))
+	resp, err := c.Aggregate(ctx, calls)
 	if err != nil {
-		return nil, fmt.Errorf("parse multicall abi: %w", err)
+		return nil, err
 	}
 
+	returnData := make([][]byte, len(resp.Results))
+	for i, r := range resp.Results {
+		returnData[i] = r.ReturnData
+	}
+	return returnData, nil
+}
+
+// Aggregate runs calls through the original aggregate method, which reverts
+// if any call fails.
+func (c *Multicall) Aggregate(
+	ctx context.Context,
+	calls []MulticallCall,
+) (*MulticallResponse, error) {
+	return c.runChunked(ctx, len(calls), func(ctx context.Context, start, end int) (*MulticallResponse, error) {
+		return c.aggregate(ctx, calls[start:end])
+	})
+}
+
+func (c *Multicall) aggregate(
+	ctx context.Context,
+	calls []MulticallCall,
+) (*MulticallResponse, error) {
 	type Call struct {
-		Target   [20]byte
+		Target   common.Address
 		CallData []byte
 	}
 
 	packedCalls := make([]Call, 0, len(calls))
 	for i, cl := range calls {
-		targetHex, err := TronBase58ToHex(cl.Target)
-		if err != nil {
-			return nil, fmt.Errorf("invalid target address[%d]: %w", i, err)
+		if cl.Target.IsZero() {
+			return nil, fmt.Errorf("empty target address[%d]", i)
 		}
-		targetRaw20, err := hex.DecodeString(targetHex[2:])
-		if err != nil {
-			return nil, fmt.Errorf("decode target hex[%d]: %w", i, err)
-		}
-
-		var target20 [20]byte
-		copy(target20[:], targetRaw20)
 
 		packedCalls = append(packedCalls, Call{
-			Target:   target20,
+			Target:   cl.Target.EVM(),
 			CallData: cl.CallData,
 		})
 	}
 
-	input, err := mcABI.Pack("aggregate", packedCalls)
+	output, err := c.c.callABI(ctx, c.multicallAddress, c.multicallAddress, &multicallABI, "aggregate", packedCalls)
 	if err != nil {
-		return nil, fmt.Errorf("pack aggregate: %w", err)
-	}
-
-	parameter := hex.EncodeToString(input[4:])
-
-	raw, err := c.c.TriggerConstantContract(ctx, TriggerConstantContractReq{
-		OwnerAddress:    c.multicallAddress,
-		ContractAddress: c.multicallAddress,
-		Function:        "aggregate((address,bytes)[])",
-		Parameter:       parameter,
-		Visible:         c.c.visible,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("trigger constant contract: %w", err)
-	}
-
-	var result struct {
-		ConstantResult []string `json:"constant_result"`
-		Result         struct {
-			Result bool `json:"result"`
-		} `json:"result"`
-		Message string `json:"message"`
-	}
-	if err := json.Unmarshal(raw, &result); err != nil {
-		return nil, fmt.Errorf("decode rpc response: %w", err)
-	}
-
-	if len(result.ConstantResult) == 0 {
-		return nil, fmt.Errorf("empty constant_result: %s", raw)
-	}
-
-	rawBytes, err := hex.DecodeString(result.ConstantResult[0])
-	if err != nil {
-		return nil, fmt.Errorf("decode constant_result hex: %w", err)
-	}
-
-	output, err := mcABI.Unpack("aggregate", rawBytes)
-	if err != nil {
-		return nil, fmt.Errorf("unpack aggregate: %w", err)
+		return nil, fmt.Errorf("aggregate: %w", err)
 	}
 
 	returnData := output[1].([][]byte)
-
 	if len(returnData) != len(calls) {
 		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(returnData), len(calls))
 	}
 
-	return returnData, nil
+	resp := &MulticallResponse{
+		BlockNumber: output[0].(*big.Int),
+		Results:     make([]MulticallResult, len(returnData)),
+	}
+	for i, data := range returnData {
+		resp.Results[i] = MulticallResult{Success: true, ReturnData: data}
+	}
+	return resp, nil
 }
 
 func UnpackMulticallResults(results [][]byte, abiJSON string, functionName string) ([]interface{}, error) {
@@ -153,8 +171,8 @@ func PackCallData(abiJSON string, functionName string, args ...interface{}) ([]b
 
 func (c *Multicall) BalanceOf(
 	ctx context.Context,
-	tokenAddress string,
-	addresses []string,
+	tokenAddress Address,
+	addresses []Address,
 ) ([]*big.Int, error) {
 	balances := make([]*big.Int, len(addresses))
 	if len(addresses) == 0 {
@@ -162,29 +180,8 @@ func (c *Multicall) BalanceOf(
 	}
 
 	calls := make([]MulticallCall, len(addresses))
-	tokenHex, err := TronBase58ToHex(tokenAddress)
-	if err != nil {
-		return nil, fmt.Errorf("invalid token address: %w", err)
-	}
-	tokenRaw20, _ := hex.DecodeString(tokenHex[2:])
-
-	var token20 [20]byte
-	copy(token20[:], tokenRaw20)
-
 	for i, address := range addresses {
-		wHex, err := TronBase58ToHex(address)
-		if err != nil {
-			return nil, fmt.Errorf("invalid address %s: %w", address, err)
-		}
-		wRaw20, err := hex.DecodeString(wHex[2:])
-		if err != nil {
-			return nil, fmt.Errorf("decode address hex %s: %w", address, err)
-		}
-		if len(wRaw20) != 20 {
-			return nil, fmt.Errorf("invalid address length %s: got %d bytes, want 20", address, len(wRaw20))
-		}
-
-		callData, err := PackCallData(erc20BalanceOfABIJSON, "balanceOf", common.BytesToAddress(wRaw20))
+		callData, err := PackCallData(erc20BalanceOfABIJSON, "balanceOf", address.EVM())
 		if err != nil {
 			return nil, fmt.Errorf("pack balanceOf: %w", err)
 		}
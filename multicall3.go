@@ -0,0 +1,203 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const multicall3ABIJSON = `[
+{"inputs":[{"components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}],"name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+{"inputs":[{"name":"requireSuccess","type":"bool"},{"components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"tryAggregate","outputs":[{"components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}],"name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+{"inputs":[{"name":"requireSuccess","type":"bool"},{"components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"tryBlockAndAggregate","outputs":[{"name":"blockNumber","type":"uint256"},{"name":"blockHash","type":"bytes32"},{"components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}],"name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"},
+{"inputs":[{"name":"addr","type":"address"}],"name":"getEthBalance","outputs":[{"name":"balance","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"getBlockNumber","outputs":[{"name":"blockNumber","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"getCurrentBlockTimestamp","outputs":[{"name":"timestamp","type":"uint256"}],"stateMutability":"view","type":"function"}
+]`
+
+var multicall3ABI = mustParseABI(multicall3ABIJSON)
+
+type Multicall3Call struct {
+	Target       Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type MulticallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+type MulticallResponse struct {
+	// BlockNumber is the block the calls ran at. Calls split into several
+	// chunks report the lowest block of any chunk.
+	BlockNumber *big.Int
+	Results     []MulticallResult
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+func toMulticallResults(v any) ([]MulticallResult, error) {
+	converted, ok := abi.ConvertType(v, new([]multicall3Result)).(*[]multicall3Result)
+	if !ok {
+		return nil, fmt.Errorf("unexpected multicall result type %T", v)
+	}
+
+	results := make([]MulticallResult, len(*converted))
+	for i, r := range *converted {
+		results[i] = MulticallResult(r)
+	}
+	return results, nil
+}
+
+// TryAggregate runs calls through tryBlockAndAggregate. With requireSuccess
+// false a reverting call yields Success=false instead of failing the batch.
+func (c *Multicall) TryAggregate(
+	ctx context.Context,
+	requireSuccess bool,
+	calls []MulticallCall,
+) (*MulticallResponse, error) {
+	return c.runChunked(ctx, len(calls), func(ctx context.Context, start, end int) (*MulticallResponse, error) {
+		return c.tryAggregate(ctx, requireSuccess, calls[start:end])
+	})
+}
+
+func (c *Multicall) tryAggregate(
+	ctx context.Context,
+	requireSuccess bool,
+	calls []MulticallCall,
+) (*MulticallResponse, error) {
+	type Call struct {
+		Target   common.Address
+		CallData []byte
+	}
+
+	packedCalls := make([]Call, 0, len(calls))
+	for i, cl := range calls {
+		if cl.Target.IsZero() {
+			return nil, fmt.Errorf("empty target address[%d]", i)
+		}
+		packedCalls = append(packedCalls, Call{Target: cl.Target.EVM(), CallData: cl.CallData})
+	}
+
+	output, err := c.c.callABI(ctx, c.multicallAddress, c.multicallAddress, &multicall3ABI, "tryBlockAndAggregate", requireSuccess, packedCalls)
+	if err != nil {
+		return nil, fmt.Errorf("tryBlockAndAggregate: %w", err)
+	}
+
+	results, err := toMulticallResults(output[2])
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	return &MulticallResponse{
+		BlockNumber: output[0].(*big.Int),
+		Results:     results,
+	}, nil
+}
+
+// Aggregate3 runs calls through aggregate3, honouring AllowFailure per call.
+// aggregate3 does not report the block, so a getBlockNumber call is appended
+// to the batch to fill BlockNumber.
+func (c *Multicall) Aggregate3(
+	ctx context.Context,
+	calls []Multicall3Call,
+) (*MulticallResponse, error) {
+	return c.runChunked(ctx, len(calls), func(ctx context.Context, start, end int) (*MulticallResponse, error) {
+		return c.aggregate3(ctx, calls[start:end])
+	})
+}
+
+func (c *Multicall) aggregate3(
+	ctx context.Context,
+	calls []Multicall3Call,
+) (*MulticallResponse, error) {
+	type Call3 struct {
+		Target       common.Address
+		AllowFailure bool
+		CallData     []byte
+	}
+
+	blockNumberCall, err := multicall3ABI.Pack("getBlockNumber")
+	if err != nil {
+		return nil, fmt.Errorf("pack getBlockNumber: %w", err)
+	}
+
+	packedCalls := make([]Call3, 0, len(calls)+1)
+	for i, cl := range calls {
+		if cl.Target.IsZero() {
+			return nil, fmt.Errorf("empty target address[%d]", i)
+		}
+		packedCalls = append(packedCalls, Call3{
+			Target:       cl.Target.EVM(),
+			AllowFailure: cl.AllowFailure,
+			CallData:     cl.CallData,
+		})
+	}
+	packedCalls = append(packedCalls, Call3{
+		Target:   c.multicallAddress.EVM(),
+		CallData: blockNumberCall,
+	})
+
+	output, err := c.c.callABI(ctx, c.multicallAddress, c.multicallAddress, &multicall3ABI, "aggregate3", packedCalls)
+	if err != nil {
+		return nil, fmt.Errorf("aggregate3: %w", err)
+	}
+
+	results, err := toMulticallResults(output[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(results) != len(packedCalls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+
+	blockNumber, err := multicall3ABI.Methods["getBlockNumber"].Outputs.Unpack(results[len(calls)].ReturnData)
+	if err != nil {
+		return nil, fmt.Errorf("unpack getBlockNumber: %w", err)
+	}
+
+	return &MulticallResponse{
+		BlockNumber: blockNumber[0].(*big.Int),
+		Results:     results[:len(calls)],
+	}, nil
+}
+
+// GetEthBalanceCall builds a call for batching that returns the TRX balance
+// of addr in sun.
+func (c *Multicall) GetEthBalanceCall(addr Address) (MulticallCall, error) {
+	data, err := multicall3ABI.Pack("getEthBalance", addr.EVM())
+	if err != nil {
+		return MulticallCall{}, fmt.Errorf("pack getEthBalance: %w", err)
+	}
+	return MulticallCall{Target: c.multicallAddress, CallData: data}, nil
+}
+
+func (c *Multicall) GetEthBalance(ctx context.Context, addr Address) (*big.Int, error) {
+	return c.callUint256(ctx, "getEthBalance", addr.EVM())
+}
+
+func (c *Multicall) GetBlockNumber(ctx context.Context) (*big.Int, error) {
+	return c.callUint256(ctx, "getBlockNumber")
+}
+
+func (c *Multicall) GetCurrentBlockTimestamp(ctx context.Context) (*big.Int, error) {
+	return c.callUint256(ctx, "getCurrentBlockTimestamp")
+}
+
+func (c *Multicall) callUint256(ctx context.Context, method string, args ...any) (*big.Int, error) {
+	out, err := c.c.callABI(ctx, c.multicallAddress, c.multicallAddress, &multicall3ABI, method, args...)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
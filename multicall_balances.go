@@ -0,0 +1,175 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+const erc20DecimalsABIJSON = `[{"inputs":[],"name":"decimals","outputs":[{"internalType":"uint8","name":"","type":"uint8"}],"stateMutability":"view","type":"function"}]`
+
+// TRXToken returns the zero Address, which Balances and BalanceMatrix take
+// for native TRX.
+func TRXToken() Address {
+	return Address{}
+}
+
+// BalanceMatrix holds balances keyed by token, then by holder. A cell whose
+// call failed carries the error instead of an amount.
+type BalanceMatrix map[Address]map[Address]Balance
+
+type Balance struct {
+	Amount Amount
+	Err    error
+}
+
+func (m BalanceMatrix) Get(token Address, holder Address) (Amount, error) {
+	b, ok := m[token][holder]
+	if !ok {
+		return Amount{}, fmt.Errorf("no balance of %s for %s", token, holder)
+	}
+	return b.Amount, b.Err
+}
+
+// Balances fetches the balance of every address in every token in a single
+// aggregate3 batch. Include TRXToken() in tokens to get native TRX via
+// getEthBalance. Token decimals are read in the same batch. Calls may fail
+// individually, e.g. for a token that is not a TRC20 contract; those cells get
+// an error wrapping ErrCallReverted and the rest of the matrix is filled.
+func (c *Multicall) Balances(
+	ctx context.Context,
+	tokens []Address,
+	addresses []Address,
+) (BalanceMatrix, error) {
+	matrix := make(BalanceMatrix, len(tokens))
+	if len(tokens) == 0 || len(addresses) == 0 {
+		for _, token := range tokens {
+			matrix[token] = make(map[Address]Balance)
+		}
+		return matrix, nil
+	}
+
+	var trc20 []Address
+	for _, token := range tokens {
+		if !token.IsZero() {
+			trc20 = append(trc20, token)
+		}
+	}
+
+	decimalsCall, err := PackCallData(erc20DecimalsABIJSON, "decimals")
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]Multicall3Call, 0, len(trc20)+len(tokens)*len(addresses))
+	for _, token := range trc20 {
+		calls = append(calls, Multicall3Call{Target: token, AllowFailure: true, CallData: decimalsCall})
+	}
+	for _, token := range tokens {
+		for _, address := range addresses {
+			var (
+				call MulticallCall
+				err  error
+			)
+			if token.IsZero() {
+				call, err = c.GetEthBalanceCall(address)
+			} else {
+				var callData []byte
+				callData, err = PackCallData(erc20BalanceOfABIJSON, "balanceOf", address.EVM())
+				call = MulticallCall{Target: token, CallData: callData}
+			}
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, Multicall3Call{Target: call.Target, AllowFailure: true, CallData: call.CallData})
+		}
+	}
+
+	resp, err := c.Aggregate3(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+	results := resp.Results
+
+	decimalsByToken := map[Address]uint8{TRXToken(): TrxDecimals}
+	decimalsErrs := make(map[Address]error)
+	for i, token := range trc20 {
+		values, errs := unpackResults(results[i:i+1], erc20DecimalsABIJSON, "decimals")
+		if errs[0] != nil {
+			decimalsErrs[token] = fmt.Errorf("decimals of %s: %w", token, errs[0])
+			continue
+		}
+		decimals, ok := values[0].(uint8)
+		if !ok {
+			decimalsErrs[token] = fmt.Errorf("invalid decimals type of %s", token)
+			continue
+		}
+		decimalsByToken[token] = decimals
+	}
+
+	results = results[len(trc20):]
+	for i, token := range tokens {
+		abiJSON, method := erc20BalanceOfABIJSON, "balanceOf"
+		if token.IsZero() {
+			abiJSON, method = multicall3ABIJSON, "getEthBalance"
+		}
+		values, errs := unpackResults(results[i*len(addresses):(i+1)*len(addresses)], abiJSON, method)
+
+		balances := make(map[Address]Balance, len(addresses))
+		for j, address := range addresses {
+			if err := decimalsErrs[token]; err != nil {
+				balances[address] = Balance{Err: err}
+				continue
+			}
+			if errs[j] != nil {
+				balances[address] = Balance{Err: fmt.Errorf("token %s: %w", token, errs[j])}
+				continue
+			}
+			balance, ok := values[j].(*big.Int)
+			if !ok {
+				balances[address] = Balance{Err: fmt.Errorf("invalid balance type of %s[%d]", token, j)}
+				continue
+			}
+			balances[address] = Balance{Amount: NewAmount(balance, decimalsByToken[token])}
+		}
+		matrix[token] = balances
+	}
+
+	return matrix, nil
+}
+
+// unpackResults decodes the single output of each successful result with
+// UnpackMulticallResults. A failed call gets ErrCallReverted; data that does
+// not decode fails every result, as the target does not implement method.
+func unpackResults(results []MulticallResult, abiJSON string, method string) ([]any, []error) {
+	values := make([]any, len(results))
+	errs := make([]error, len(results))
+
+	var (
+		data    [][]byte
+		indexes []int
+	)
+	for i, r := range results {
+		if !r.Success {
+			errs[i] = fmt.Errorf("%s: %w", method, ErrCallReverted)
+			continue
+		}
+		data = append(data, r.ReturnData)
+		indexes = append(indexes, i)
+	}
+
+	unpacked, err := UnpackMulticallResults(data, abiJSON, method)
+	for k, i := range indexes {
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		out := unpacked[k].([]interface{})
+		if len(out) != 1 {
+			errs[i] = fmt.Errorf("unpack %s: got %d values, want 1", method, len(out))
+			continue
+		}
+		values[i] = out[0]
+	}
+	return values, errs
+}
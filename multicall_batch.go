@@ -0,0 +1,140 @@
+package tron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+var (
+	ErrBatchNotExecuted = errors.New("multicall batch not executed")
+	ErrCallReverted     = errors.New("multicall call reverted")
+)
+
+// MulticallBatch collects heterogeneous calls, each with its own ABI method
+// and result type, and executes them in one aggregate3 round trip.
+//
+//	batch := mc.NewBatch()
+//	bal := tron.Queue[*big.Int](batch, usdt, &erc20ABI, "balanceOf", holder)
+//	dec := tron.Queue[uint8](batch, usdt, &erc20ABI, "decimals")
+//	if _, err := batch.Execute(ctx); err != nil { ... }
+//	balance, err := bal.Get()
+type MulticallBatch struct {
+	m        *Multicall
+	calls    []Multicall3Call
+	decoders []func(MulticallResult)
+	executed bool
+}
+
+func (c *Multicall) NewBatch() *MulticallBatch {
+	return &MulticallBatch{m: c}
+}
+
+func (b *MulticallBatch) Len() int {
+	return len(b.calls)
+}
+
+// Pending is the typed result of a queued call, available after Execute.
+type Pending[T any] struct {
+	batch *MulticallBatch
+	value T
+	err   error
+}
+
+func (p *Pending[T]) Get() (T, error) {
+	if p.err == nil && !p.batch.executed {
+		var zero T
+		return zero, ErrBatchNotExecuted
+	}
+	return p.value, p.err
+}
+
+// Queue adds a call of method on contractABI at target. The outputs are
+// copied into T: a single output into its Go type, several outputs into a
+// struct whose fields match the camel-cased output names (e.g. getReserves
+// into struct{ Reserve0, Reserve1 *big.Int; BlockTimestampLast uint32 }).
+// Address arguments are converted to their EVM form.
+func Queue[T any](b *MulticallBatch, target Address, contractABI *abi.ABI, method string, args ...any) *Pending[T] {
+	return QueueFunc(b, target, contractABI, method, func(m abi.Method, out []any) (T, error) {
+		var v T
+		if err := m.Outputs.Copy(&v, out); err != nil {
+			return v, err
+		}
+		return v, nil
+	}, args...)
+}
+
+// QueueFunc is Queue with a custom decoder for the unpacked outputs.
+func QueueFunc[T any](
+	b *MulticallBatch,
+	target Address,
+	contractABI *abi.ABI,
+	method string,
+	decode func(m abi.Method, out []any) (T, error),
+	args ...any,
+) *Pending[T] {
+	p := &Pending[T]{batch: b}
+
+	m, ok := contractABI.Methods[method]
+	if !ok {
+		p.err = fmt.Errorf("method %s not found in abi", method)
+		return p
+	}
+
+	packArgs := make([]any, len(args))
+	for i, arg := range args {
+		if a, ok := arg.(Address); ok {
+			arg = a.EVM()
+		}
+		packArgs[i] = arg
+	}
+	callData, err := contractABI.Pack(method, packArgs...)
+	if err != nil {
+		p.err = fmt.Errorf("pack %s: %w", method, err)
+		return p
+	}
+
+	b.calls = append(b.calls, Multicall3Call{
+		Target:       target,
+		AllowFailure: true,
+		CallData:     callData,
+	})
+	b.decoders = append(b.decoders, func(r MulticallResult) {
+		if !r.Success {
+			p.err = fmt.Errorf("%s: %w", method, ErrCallReverted)
+			return
+		}
+		out, err := m.Outputs.Unpack(r.ReturnData)
+		if err != nil {
+			p.err = fmt.Errorf("unpack %s: %w", method, err)
+			return
+		}
+		if p.value, err = decode(m, out); err != nil {
+			p.err = fmt.Errorf("decode %s: %w", method, err)
+		}
+	})
+	return p
+}
+
+// Execute runs the queued calls and resolves their Pending results. A
+// reverting call fails only its own result; the returned error is for the
+// batch as a whole.
+func (b *MulticallBatch) Execute(ctx context.Context) (*big.Int, error) {
+	if b.executed {
+		return nil, errors.New("multicall batch already executed")
+	}
+
+	resp, err := b.m.Aggregate3(ctx, b.calls)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, decode := range b.decoders {
+		decode(resp.Results[i])
+	}
+	b.executed = true
+	return resp.BlockNumber, nil
+}
@@ -0,0 +1,69 @@
+package tron_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+const mockTokenABIJSON = `[
+{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"decimals","outputs":[{"name":"","type":"uint8"}],"stateMutability":"view","type":"function"},
+{"inputs":[],"name":"name","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"}
+]`
+
+func TestMulticallBatch(t *testing.T) {
+	ctx := context.Background()
+	tokenABI, err := abi.JSON(strings.NewReader(mockTokenABIJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	alice := trontest.NewKey("alice").Address
+	node, addr, token := multicallFixture(t, alice)
+	batch := node.Client().NewMulticall(addr).NewBatch()
+
+	balance := tron.Queue[*big.Int](batch, token, &tokenABI, "balanceOf", alice)
+	decimals := tron.Queue[uint8](batch, token, &tokenABI, "decimals")
+	name := tron.QueueFunc(batch, token, &tokenABI, "name", func(m abi.Method, out []any) (string, error) {
+		return strings.ToUpper(out[0].(string)), nil
+	})
+	reverted := tron.Queue[uint8](batch, addr, &tokenABI, "decimals")
+	unknown := tron.Queue[uint8](batch, token, &tokenABI, "symbol")
+	if batch.Len() != 4 {
+		t.Fatalf("Len = %d, want 4 queued calls", batch.Len())
+	}
+
+	if _, err := balance.Get(); !errors.Is(err, tron.ErrBatchNotExecuted) {
+		t.Fatalf("Get before Execute: err = %v, want ErrBatchNotExecuted", err)
+	}
+	if _, err := unknown.Get(); err == nil || errors.Is(err, tron.ErrBatchNotExecuted) {
+		t.Fatalf("Get of an unknown method: err = %v, want a queueing error", err)
+	}
+
+	number, err := batch.Execute(ctx)
+	if err != nil || number.Int64() != node.BlockNumber() {
+		t.Fatalf("Execute = %v, %v; want block %d", number, err, node.BlockNumber())
+	}
+	if v, err := balance.Get(); err != nil || v.Int64() != 1000 {
+		t.Fatalf("balance = %v, %v; want 1000", v, err)
+	}
+	if v, err := decimals.Get(); err != nil || v != 6 {
+		t.Fatalf("decimals = %v, %v; want 6", v, err)
+	}
+	if v, err := name.Get(); err != nil || v != "MOCK TOKEN" {
+		t.Fatalf("name = %q, %v; want MOCK TOKEN", v, err)
+	}
+	if _, err := reverted.Get(); !errors.Is(err, tron.ErrCallReverted) {
+		t.Fatalf("reverted call: err = %v, want ErrCallReverted", err)
+	}
+
+	if _, err := batch.Execute(ctx); err == nil {
+		t.Fatal("a second Execute succeeded")
+	}
+}
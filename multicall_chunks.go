@@ -0,0 +1,141 @@
+package tron
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+type chunkFunc func(ctx context.Context, start, end int) (*MulticallResponse, error)
+
+type chunkRange struct {
+	start, end int
+}
+
+// runChunked splits n calls into chunks of at most chunkSize, executes up to
+// concurrency chunks at once and reassembles the results in call order. A
+// chunk the node rejects as too large is bisected until the pieces fit. Any
+// other error, a revert included, fails the whole run: a reverting call is
+// not isolated, so use AllowFailure or TryAggregate to tolerate one.
+// Chunks may run at different blocks; BlockNumber is the lowest of them, the
+// block every result is at least as recent as. No calls make no request and
+// an empty response.
+func (c *Multicall) runChunked(ctx context.Context, n int, exec chunkFunc) (*MulticallResponse, error) {
+	if n == 0 {
+		return &MulticallResponse{Results: []MulticallResult{}}, nil
+	}
+	if n <= c.chunkSize {
+		return c.bisect(ctx, 0, n, exec)
+	}
+
+	c.c.logger.DebugContext(ctx, "multicall split into chunks",
+		"calls", n, "chunk_size", c.chunkSize, "concurrency", c.concurrency)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resp := &MulticallResponse{Results: make([]MulticallResult, n)}
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+	)
+
+	chunks := make(chan chunkRange)
+	workers := min(c.concurrency, (n+c.chunkSize-1)/c.chunkSize)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ch := range chunks {
+				part, err := c.bisect(ctx, ch.start, ch.end, exec)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					copy(resp.Results[ch.start:ch.end], part.Results)
+					if resp.BlockNumber == nil || (part.BlockNumber != nil && part.BlockNumber.Cmp(resp.BlockNumber) < 0) {
+						resp.BlockNumber = part.BlockNumber
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+send:
+	for start := 0; start < n; start += c.chunkSize {
+		select {
+		case chunks <- chunkRange{start: start, end: min(start+c.chunkSize, n)}:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(chunks)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Multicall) bisect(ctx context.Context, start, end int, exec chunkFunc) (*MulticallResponse, error) {
+	resp, err := exec(ctx, start, end)
+	if err == nil {
+		if len(resp.Results) != end-start {
+			return nil, fmt.Errorf("multicall returned %d results for %d calls", len(resp.Results), end-start)
+		}
+		return resp, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !shouldBisect(err) {
+		return nil, err
+	}
+	if end-start <= 1 {
+		return nil, fmt.Errorf("call[%d]: %w", start, err)
+	}
+	c.c.logger.WarnContext(ctx, "multicall chunk too large, bisecting",
+		"start", start, "end", end, errorAttrs(err))
+
+	mid := start + (end-start)/2
+	left, err := c.bisect(ctx, start, mid, exec)
+	if err != nil {
+		return nil, err
+	}
+	right, err := c.bisect(ctx, mid, end, exec)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &MulticallResponse{
+		BlockNumber: left.BlockNumber,
+		Results:     append(left.Results, right.Results...),
+	}
+	if right.BlockNumber != nil && (merged.BlockNumber == nil || right.BlockNumber.Cmp(merged.BlockNumber) < 0) {
+		merged.BlockNumber = right.BlockNumber
+	}
+	return merged, nil
+}
+
+// shouldBisect reports whether a failed chunk may succeed in smaller pieces,
+// which is only when the request was too large. A revert would repeat in the
+// half holding the failing call, and transport, auth, rate limit and server
+// errors in every half.
+func shouldBisect(err error) bool {
+	switch StatusCode(err) {
+	case http.StatusRequestEntityTooLarge, http.StatusRequestURITooLong:
+		return true
+	}
+	return false
+}
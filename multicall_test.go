@@ -0,0 +1,234 @@
+package tron_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// multicallFixture deploys a Multicall3 mock and a token holding 1000 for
+// each of holders.
+func multicallFixture(t *testing.T, holders ...tron.Address) (node *trontest.Node, multicall, token tron.Address) {
+	t.Helper()
+
+	node = trontest.NewNode()
+	t.Cleanup(node.Close)
+
+	storage := make(map[common.Hash]common.Hash)
+	for _, h := range holders {
+		storage[slot(h)] = common.BigToHash(big.NewInt(1000))
+	}
+	token = deploy(t, node, mockTRC20(false), storage)
+	multicall = deploy(t, node, mockMulticall3(), nil)
+	return node, multicall, token
+}
+
+func balanceOfCall(t *testing.T, token, holder tron.Address) tron.MulticallCall {
+	t.Helper()
+
+	data, err := tron.PackCallData(erc20ABIJSON, "balanceOf", holder.EVM())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tron.MulticallCall{Target: token, CallData: data}
+}
+
+const erc20ABIJSON = `[{"inputs":[{"name":"account","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`
+
+func TestMulticallEmpty(t *testing.T) {
+	ctx := context.Background()
+	node, addr, _ := multicallFixture(t)
+
+	var calls atomic.Int64
+	mc := node.Client(tron.WithInterceptor(countCalls(&calls, nil))).NewMulticall(addr)
+
+	for name, run := range map[string]func() (*tron.MulticallResponse, error){
+		"Aggregate":    func() (*tron.MulticallResponse, error) { return mc.Aggregate(ctx, nil) },
+		"TryAggregate": func() (*tron.MulticallResponse, error) { return mc.TryAggregate(ctx, false, nil) },
+		"Aggregate3":   func() (*tron.MulticallResponse, error) { return mc.Aggregate3(ctx, nil) },
+	} {
+		resp, err := run()
+		if err != nil || resp == nil || len(resp.Results) != 0 {
+			t.Fatalf("%s = %+v, %v; want an empty response", name, resp, err)
+		}
+	}
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("node calls = %d, want 0", n)
+	}
+}
+
+func TestMulticall3(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := trontest.NewKey("alice").Address, trontest.NewKey("bob").Address
+	node, addr, token := multicallFixture(t, alice, bob)
+	node.Fund(alice, 5_000_000)
+	mc := node.Client().NewMulticall(addr)
+
+	balance, err := mc.GetEthBalance(ctx, alice)
+	if err != nil || balance.Int64() != 5_000_000 {
+		t.Fatalf("GetEthBalance = %v, %v; want 5000000", balance, err)
+	}
+	number, err := mc.GetBlockNumber(ctx)
+	if err != nil || number.Int64() != node.BlockNumber() {
+		t.Fatalf("GetBlockNumber = %v, %v; want %d", number, err, node.BlockNumber())
+	}
+
+	balances, err := mc.BalanceOf(ctx, token, []tron.Address{alice, bob})
+	if err != nil || len(balances) != 2 || balances[0].Int64() != 1000 || balances[1].Int64() != 1000 {
+		t.Fatalf("BalanceOf = %v, %v; want [1000 1000]", balances, err)
+	}
+
+	// The multicall contract itself has no balanceOf, so this call reverts.
+	bad := balanceOfCall(t, addr, alice)
+	good := balanceOfCall(t, token, bob)
+
+	resp, err := mc.TryAggregate(ctx, false, []tron.MulticallCall{good, bad})
+	if err != nil {
+		t.Fatalf("TryAggregate: %v", err)
+	}
+	if !resp.Results[0].Success || resp.Results[1].Success || resp.BlockNumber.Int64() != node.BlockNumber() {
+		t.Fatalf("TryAggregate = %+v, want the second call to fail", resp)
+	}
+	if _, err := mc.TryAggregate(ctx, true, []tron.MulticallCall{good, bad}); err == nil {
+		t.Fatal("TryAggregate with requireSuccess succeeded despite a reverting call")
+	}
+
+	resp, err = mc.Aggregate3(ctx, []tron.Multicall3Call{
+		{Target: token, CallData: good.CallData},
+		{Target: addr, AllowFailure: true, CallData: bad.CallData},
+	})
+	if err != nil {
+		t.Fatalf("Aggregate3: %v", err)
+	}
+	if !resp.Results[0].Success || resp.Results[1].Success || new(big.Int).SetBytes(resp.Results[0].ReturnData).Int64() != 1000 {
+		t.Fatalf("Aggregate3 = %+v", resp)
+	}
+	if _, err := mc.Aggregate3(ctx, []tron.Multicall3Call{{Target: addr, CallData: bad.CallData}}); err == nil {
+		t.Fatal("Aggregate3 succeeded despite a reverting call without AllowFailure")
+	}
+}
+
+func TestMulticallChunks(t *testing.T) {
+	ctx := context.Background()
+	holders := make([]tron.Address, 5)
+	for i := range holders {
+		holders[i] = trontest.NewKey(string(rune('a' + i))).Address
+	}
+	node, addr, token := multicallFixture(t, holders[:4]...)
+
+	calls := make([]tron.Multicall3Call, len(holders))
+	for i, h := range holders {
+		calls[i] = tron.Multicall3Call{Target: token, CallData: balanceOfCall(t, token, h).CallData}
+	}
+
+	// Every chunk lands one block later; the response reports the first.
+	start := node.BlockNumber()
+	var requests atomic.Int64
+	nextBlock := func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			requests.Add(1)
+			defer node.ProduceBlock()
+			return next(ctx, path, req, out)
+		}
+	}
+	mc := node.Client(tron.WithInterceptor(nextBlock)).NewMulticall(addr, tron.WithChunkSize(2), tron.WithConcurrency(1))
+	resp, err := mc.Aggregate3(ctx, calls)
+	if err != nil {
+		t.Fatalf("Aggregate3: %v", err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Fatalf("requests = %d, want 3 chunks", n)
+	}
+	if resp.BlockNumber.Int64() != start {
+		t.Fatalf("BlockNumber = %v, want the lowest chunk block %d", resp.BlockNumber, start)
+	}
+	for i, r := range resp.Results {
+		want := int64(1000)
+		if i == 4 {
+			want = 0
+		}
+		if got := new(big.Int).SetBytes(r.ReturnData).Int64(); !r.Success || got != want {
+			t.Fatalf("result[%d] = %+v, want balance %d", i, r, want)
+		}
+	}
+}
+
+func TestMulticallBisect(t *testing.T) {
+	ctx := context.Background()
+	alice := trontest.NewKey("alice").Address
+	node, addr, token := multicallFixture(t, alice)
+	good := balanceOfCall(t, token, alice)
+
+	// The node turns the first request away as too large.
+	var requests atomic.Int64
+	tooLarge := func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			if requests.Add(1) == 1 {
+				return &tron.APIError{StatusCode: http.StatusRequestEntityTooLarge}
+			}
+			return next(ctx, path, req, out)
+		}
+	}
+	mc := node.Client(tron.WithInterceptor(tooLarge)).NewMulticall(addr)
+	resp, err := mc.Aggregate(ctx, []tron.MulticallCall{good, good, good, good})
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if n := requests.Load(); n != 3 || len(resp.Results) != 4 {
+		t.Fatalf("requests = %d, results = %d; want 3 and 4", n, len(resp.Results))
+	}
+
+	// A revert is not bisected: it fails the run after one request.
+	requests.Store(1)
+	_, err = mc.Aggregate(ctx, []tron.MulticallCall{good, good, balanceOfCall(t, addr, alice), good})
+	var callErr *tron.ContractCallError
+	if !errors.As(err, &callErr) {
+		t.Fatalf("Aggregate error = %v, want a ContractCallError", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Fatalf("requests = %d, want 1 more", n)
+	}
+}
+
+func TestMulticallBalances(t *testing.T) {
+	ctx := context.Background()
+	alice, bob := trontest.NewKey("alice").Address, trontest.NewKey("bob").Address
+	node, addr, token := multicallFixture(t, alice)
+	node.Fund(alice, 5_000_000)
+	mc := node.Client().NewMulticall(addr)
+
+	// addr has no decimals and reverts; bob is an account, whose empty
+	// return data does not decode.
+	matrix, err := mc.Balances(ctx, []tron.Address{tron.TRXToken(), token, addr, bob}, []tron.Address{alice, bob})
+	if err != nil {
+		t.Fatalf("Balances: %v", err)
+	}
+
+	for _, tc := range []struct {
+		token, holder tron.Address
+		want          string
+	}{
+		{tron.TRXToken(), alice, "5.000000"},
+		{tron.TRXToken(), bob, "0.000000"},
+		{token, alice, "0.001000"},
+		{token, bob, "0.000000"},
+	} {
+		got, err := matrix.Get(tc.token, tc.holder)
+		if err != nil || got.String() != tc.want {
+			t.Fatalf("balance of %s in %s = %s, %v; want %s", tc.holder, tc.token, got, err, tc.want)
+		}
+	}
+	if _, err := matrix.Get(addr, alice); !errors.Is(err, tron.ErrCallReverted) {
+		t.Fatalf("balance in a reverting token: err = %v, want ErrCallReverted", err)
+	}
+	if _, err := matrix.Get(bob, alice); err == nil || errors.Is(err, tron.ErrCallReverted) {
+		t.Fatalf("balance in an account: err = %v, want a decode error", err)
+	}
+}
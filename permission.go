@@ -0,0 +1,324 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// PermissionType is sent as a number; java-tron returns it as the enum name
+// ("Owner", "Witness", "Active"), so both forms are accepted when decoding.
+type PermissionType int
+
+const (
+	PermissionTypeOwner   PermissionType = 0
+	PermissionTypeWitness PermissionType = 1
+	PermissionTypeActive  PermissionType = 2
+)
+
+var permissionTypeNames = map[string]PermissionType{
+	"Owner":   PermissionTypeOwner,
+	"Witness": PermissionTypeWitness,
+	"Active":  PermissionTypeActive,
+}
+
+func (t *PermissionType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		v, ok := permissionTypeNames[name]
+		if !ok {
+			return fmt.Errorf("unknown permission type %q", name)
+		}
+		*t = v
+		return nil
+	}
+
+	var n int
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("invalid permission type %s", b)
+	}
+	*t = PermissionType(n)
+	return nil
+}
+
+const (
+	OwnerPermissionID = 0
+	// Active permissions are numbered from 2 in the order they are set.
+	FirstActivePermissionID = 2
+)
+
+// TxOption adjusts a transaction built by the high-level builders.
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	permissionID int
+}
+
+// WithPermissionID builds the transaction for a permission other than the
+// owner's, so it can be signed by the keys of an active permission.
+func WithPermissionID(id int) TxOption {
+	return func(o *txOptions) { o.permissionID = id }
+}
+
+func applyTxOptions(opts []TxOption) txOptions {
+	var o txOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+const (
+	SignWeightEnough    = "ENOUGH_PERMISSION"
+	SignWeightNotEnough = "NOT_ENOUGH_PERMISSION"
+)
+
+type ContractType int
+
+const (
+	AccountCreateContract           ContractType = 0
+	TransferContract                ContractType = 1
+	TransferAssetContract           ContractType = 2
+	VoteWitnessContract             ContractType = 4
+	WitnessCreateContract           ContractType = 5
+	AssetIssueContract              ContractType = 6
+	WitnessUpdateContract           ContractType = 8
+	ParticipateAssetIssueContract   ContractType = 9
+	AccountUpdateContract           ContractType = 10
+	FreezeBalanceContract           ContractType = 11
+	UnfreezeBalanceContract         ContractType = 12
+	WithdrawBalanceContract         ContractType = 13
+	UnfreezeAssetContract           ContractType = 14
+	UpdateAssetContract             ContractType = 15
+	ProposalCreateContract          ContractType = 16
+	ProposalApproveContract         ContractType = 17
+	ProposalDeleteContract          ContractType = 18
+	SetAccountIDContract            ContractType = 19
+	CreateSmartContract             ContractType = 30
+	TriggerSmartContract            ContractType = 31
+	UpdateSettingContract           ContractType = 33
+	ExchangeCreateContract          ContractType = 41
+	ExchangeInjectContract          ContractType = 42
+	ExchangeWithdrawContract        ContractType = 43
+	ExchangeTransactionContract     ContractType = 44
+	UpdateEnergyLimitContract       ContractType = 45
+	AccountPermissionUpdateContract ContractType = 46
+	ClearABIContract                ContractType = 48
+	UpdateBrokerageContract         ContractType = 49
+	MarketSellAssetContract         ContractType = 52
+	MarketCancelOrderContract       ContractType = 53
+	FreezeBalanceV2Contract         ContractType = 54
+	UnfreezeBalanceV2Contract       ContractType = 55
+	WithdrawExpireUnfreezeContract  ContractType = 56
+	DelegateResourceContract        ContractType = 57
+	UnDelegateResourceContract      ContractType = 58
+	CancelAllUnfreezeV2Contract     ContractType = 59
+)
+
+// PermissionOperations builds the 32-byte operations bitmask of an active
+// permission: bit N is set when contract type N is allowed.
+func PermissionOperations(types ...ContractType) string {
+	var ops [32]byte
+	for _, t := range types {
+		if t < 0 || int(t) >= len(ops)*8 {
+			continue
+		}
+		ops[t/8] |= 1 << (t % 8)
+	}
+	return hex.EncodeToString(ops[:])
+}
+
+func PermissionAllows(operations string, t ContractType) (bool, error) {
+	ops, err := hex.DecodeString(strings.TrimPrefix(operations, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("decode operations: %w", err)
+	}
+	if t < 0 || int(t) >= len(ops)*8 {
+		return false, nil
+	}
+	return ops[t/8]&(1<<(t%8)) != 0, nil
+}
+
+type PermissionKey struct {
+	Address Address `json:"address"`
+	Weight  int64   `json:"weight"`
+}
+
+type Permission struct {
+	Type           PermissionType  `json:"type,omitempty"`
+	ID             int             `json:"id,omitempty"`
+	PermissionName string          `json:"permission_name,omitempty"`
+	Threshold      int64           `json:"threshold"`
+	ParentID       int             `json:"parent_id,omitempty"`
+	Operations     string          `json:"operations,omitempty"`
+	Keys           []PermissionKey `json:"keys"`
+}
+
+type AccountPermissionUpdateReq struct {
+	OwnerAddress Address      `json:"owner_address"`
+	Owner        Permission   `json:"owner"`
+	Witness      *Permission  `json:"witness,omitempty"`
+	Actives      []Permission `json:"actives"`
+	Visible      bool         `json:"visible,omitempty"`
+}
+
+func (c *Client) AccountPermissionUpdate(ctx context.Context, req AccountPermissionUpdateReq) (Raw, error) {
+	if len(req.Actives) == 0 {
+		return nil, errors.New("at least one active permission is required")
+	}
+
+	req.Owner.Type = PermissionTypeOwner
+	if req.Witness != nil {
+		req.Witness.Type = PermissionTypeWitness
+	}
+	for i := range req.Actives {
+		req.Actives[i].Type = PermissionTypeActive
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "accountpermissionupdate", req, &out)
+	return out, err
+}
+
+type AccountPermissions struct {
+	Owner   *Permission  `json:"owner_permission"`
+	Witness *Permission  `json:"witness_permission,omitempty"`
+	Actives []Permission `json:"active_permission"`
+}
+
+// Permission returns the owner permission for id 0 and the matching active
+// permission otherwise.
+func (p *AccountPermissions) Permission(id int) (*Permission, error) {
+	if id == OwnerPermissionID {
+		if p.Owner == nil {
+			return nil, errors.New("account has no owner permission")
+		}
+		return p.Owner, nil
+	}
+	for i := range p.Actives {
+		if p.Actives[i].ID == id {
+			return &p.Actives[i], nil
+		}
+	}
+	return nil, fmt.Errorf("permission %d not found", id)
+}
+
+func (c *Client) GetAccountPermissions(ctx context.Context, address Address) (*AccountPermissions, error) {
+	raw, err := c.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var out AccountPermissions
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type SignResult struct {
+	Code    string `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type ApprovedListResp struct {
+	Result       SignResult      `json:"result"`
+	ApprovedList []string        `json:"approved_list"`
+	Transaction  json.RawMessage `json:"transaction,omitempty"`
+}
+
+func (c *Client) GetApprovedList(ctx context.Context, signedTx []byte) (*ApprovedListResp, error) {
+	var out ApprovedListResp
+	if err := c.Call(ctx, "getapprovedlist", json.RawMessage(signedTx), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type SignWeightResp struct {
+	Result        SignResult      `json:"result"`
+	Permission    *Permission     `json:"permission,omitempty"`
+	ApprovedList  []string        `json:"approved_list"`
+	CurrentWeight int64           `json:"current_weight"`
+	Transaction   json.RawMessage `json:"transaction,omitempty"`
+}
+
+func (c *Client) GetSignWeight(ctx context.Context, signedTx []byte) (*SignWeightResp, error) {
+	var out SignWeightResp
+	if err := c.Call(ctx, "getsignweight", json.RawMessage(signedTx), &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// IsThresholdMet reports whether the signatures collected so far carry enough
+// weight for the permission the transaction was built with.
+func (c *Client) IsThresholdMet(ctx context.Context, signedTx []byte) (bool, error) {
+	sw, err := c.GetSignWeight(ctx, signedTx)
+	if err != nil {
+		return false, err
+	}
+
+	switch sw.Result.Code {
+	case SignWeightEnough:
+		return true, nil
+	case SignWeightNotEnough:
+		return false, nil
+	}
+
+	if sw.Result.Message != "" {
+		return false, fmt.Errorf("getsignweight: %s: %s", sw.Result.Code, sw.Result.Message)
+	}
+	return false, fmt.Errorf("getsignweight: %s", sw.Result.Code)
+}
+
+// MergeSignatures combines copies of the same transaction signed by different
+// parties into one transaction carrying every distinct signature.
+func MergeSignatures(txs ...[]byte) ([]byte, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("no transactions to merge")
+	}
+
+	var merged TronTx
+	if err := json.Unmarshal(txs[0], &merged); err != nil {
+		return nil, fmt.Errorf("unmarshal tx[0]: %w", err)
+	}
+
+	seen := make(map[string]bool, len(merged.Signature))
+	sigs := make([]string, 0, len(merged.Signature))
+	for _, s := range merged.Signature {
+		key := strings.ToLower(s)
+		if !seen[key] {
+			seen[key] = true
+			sigs = append(sigs, s)
+		}
+	}
+
+	for i, b := range txs[1:] {
+		var tx TronTx
+		if err := json.Unmarshal(b, &tx); err != nil {
+			return nil, fmt.Errorf("unmarshal tx[%d]: %w", i+1, err)
+		}
+		if !strings.EqualFold(tx.RawDataHex, merged.RawDataHex) {
+			return nil, fmt.Errorf("tx[%d]: raw_data_hex differs from tx[0]", i+1)
+		}
+		for _, s := range tx.Signature {
+			key := strings.ToLower(s)
+			if !seen[key] {
+				seen[key] = true
+				sigs = append(sigs, s)
+			}
+		}
+	}
+	merged.Signature = sigs
+
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("marshal merged tx: %w", err)
+	}
+	return out, nil
+}
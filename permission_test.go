@@ -0,0 +1,226 @@
+package tron_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestPermissionTypeUnmarshal(t *testing.T) {
+	for in, want := range map[string]tron.PermissionType{
+		`"Owner"`:  tron.PermissionTypeOwner,
+		`"Active"`: tron.PermissionTypeActive,
+		`2`:        tron.PermissionTypeActive,
+	} {
+		var got tron.PermissionType
+		if err := json.Unmarshal([]byte(in), &got); err != nil {
+			t.Fatalf("unmarshal %s: %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("unmarshal %s = %d, want %d", in, got, want)
+		}
+	}
+
+	var got tron.PermissionType
+	if err := json.Unmarshal([]byte(`"Admin"`), &got); err == nil {
+		t.Fatal("unmarshal of an unknown name succeeded")
+	}
+}
+
+func TestPermissionOperations(t *testing.T) {
+	ops := tron.PermissionOperations(tron.TransferContract, tron.TriggerSmartContract)
+	for ct, want := range map[tron.ContractType]bool{
+		tron.TransferContract:      true,
+		tron.TriggerSmartContract:  true,
+		tron.TransferAssetContract: false,
+	} {
+		got, err := tron.PermissionAllows(ops, ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("PermissionAllows(%s) = %v, want %v", ct, got, want)
+		}
+	}
+}
+
+func TestMultisigTransferTRX(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	owner, carol, dave, erin := trontest.NewKey("owner"), trontest.NewKey("carol"), trontest.NewKey("dave"), trontest.NewKey("erin")
+	node.Fund(owner.Address, 100_000_000)
+	node.SetPermissions(owner.Address,
+		tron.Permission{Threshold: 1, Keys: []tron.PermissionKey{{Address: owner.Address, Weight: 1}}},
+		tron.Permission{
+			PermissionName: "payments",
+			Threshold:      2,
+			Operations:     tron.PermissionOperations(tron.TransferContract),
+			Keys: []tron.PermissionKey{
+				{Address: carol.Address, Weight: 1},
+				{Address: dave.Address, Weight: 1},
+			},
+		},
+	)
+	c := node.Client()
+
+	perms, err := c.GetAccountPermissions(ctx, owner.Address)
+	if err != nil {
+		t.Fatalf("get permissions: %v", err)
+	}
+	if len(perms.Actives) != 1 || perms.Actives[0].ID != tron.FirstActivePermissionID {
+		t.Fatalf("active permissions = %+v, want one with id 2", perms.Actives)
+	}
+
+	tx, err := c.BuildTransferTRXTx(ctx, owner.Address, erin.Address, big.NewInt(1_000_000), tron.WithPermissionID(tron.FirstActivePermissionID))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	byCarol, err := tron.SignTransaction(tx, carol.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byDave, err := tron.SignTransaction(tx, dave.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byOwner, err := tron.SignTransaction(tx, owner.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, signed := range map[string][]byte{"one signer": byCarol, "owner key": byOwner} {
+		resp, err := c.BroadcastTransaction(ctx, signed)
+		if err != nil {
+			t.Fatalf("%s: broadcast: %v", name, err)
+		}
+		if resp.Result || resp.Code != "SIGERROR" {
+			t.Fatalf("%s: broadcast = %+v, want SIGERROR", name, resp)
+		}
+	}
+
+	both, err := tron.MergeSignatures(byCarol, byDave)
+	if err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+	check, err := c.VerifyTransactionPermissions(ctx, both)
+	if err != nil {
+		t.Fatalf("verify permissions: %v", err)
+	}
+	if check.PermissionID != tron.FirstActivePermissionID || check.CurrentWeight != 2 || !check.ThresholdMet() {
+		t.Fatalf("permission check = %+v, want weight 2 on permission 2", check)
+	}
+
+	resp, err := c.BroadcastTransaction(ctx, both)
+	if err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if !resp.Result {
+		t.Fatalf("broadcast rejected: %s %s", resp.Code, resp.Message)
+	}
+	if got := node.Balance(erin.Address); got != 1_000_000 {
+		t.Fatalf("erin balance = %d, want 1000000", got)
+	}
+}
+
+func TestVerifyTransactionPermissionsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	owner, carol, dave := trontest.NewKey("owner"), trontest.NewKey("carol"), trontest.NewKey("dave")
+	node.Fund(owner.Address, 100_000_000)
+	node.SetPermissions(owner.Address,
+		tron.Permission{Threshold: 1, Keys: []tron.PermissionKey{{Address: owner.Address, Weight: 1}}},
+		tron.Permission{
+			Threshold:  2,
+			Operations: tron.PermissionOperations(tron.TransferContract),
+			Keys: []tron.PermissionKey{
+				{Address: carol.Address, Weight: 1},
+				{Address: dave.Address, Weight: 1},
+			},
+		},
+	)
+	c := node.Client()
+
+	tx, err := c.BuildTransferTRXTx(ctx, owner.Address, dave.Address, big.NewInt(1), tron.WithPermissionID(tron.FirstActivePermissionID))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, carol.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same key again, with an Ethereum-style recovery id so the
+	// signature bytes differ.
+	var stx tron.TronTx
+	if err := json.Unmarshal(signed, &stx); err != nil {
+		t.Fatal(err)
+	}
+	sig := stx.Signature[0]
+	v := sig[len(sig)-2:]
+	if v == "00" {
+		v = "1b"
+	} else {
+		v = "1c"
+	}
+	stx.Signature = append(stx.Signature, sig[:len(sig)-2]+v)
+	twice, err := json.Marshal(stx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := c.VerifyTransactionPermissions(ctx, twice)
+	if err != nil {
+		t.Fatalf("verify permissions: %v", err)
+	}
+	if len(check.Duplicates) != 1 || check.Duplicates[0] != carol.Address {
+		t.Fatalf("duplicates = %v, want [%s]", check.Duplicates, carol.Address)
+	}
+	if check.CurrentWeight != 1 || check.ThresholdMet() {
+		t.Fatalf("permission check = %+v, want weight 1 and threshold not met", check)
+	}
+
+	resp, err := c.BroadcastTransaction(ctx, twice)
+	if err != nil {
+		t.Fatalf("broadcast: %v", err)
+	}
+	if resp.Result || resp.Code != "SIGERROR" {
+		t.Fatalf("broadcast = %+v, want SIGERROR", resp)
+	}
+}
+
+// TransferAssetContract keeps owner_address in field 2, after asset_name.
+func TestVerifyTransactionPermissionsTRC10(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	issuer, bob := trontest.NewKey("issuer"), trontest.NewKey("bob")
+	node.Fund(issuer.Address, 100_000_000)
+	id := node.IssueAsset(tron.AssetIssue{OwnerAddress: issuer.Address, Name: "Gold", Abbr: "GLD", TotalSupply: 1_000})
+	c := node.Client()
+
+	tx, err := c.BuildTransferTRC10Tx(ctx, issuer.Address, bob.Address, id, tron.NewAmount(big.NewInt(5), 0))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, issuer.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := c.VerifyTransactionPermissions(ctx, signed)
+	if err != nil {
+		t.Fatalf("verify permissions: %v", err)
+	}
+	if check.Owner != issuer.Address || !check.ThresholdMet() {
+		t.Fatalf("permission check = %+v, want threshold met for %s", check, issuer.Address)
+	}
+}
@@ -0,0 +1,129 @@
+package tron
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Field numbers of protocol.Transaction.raw and protocol.Transaction.Contract.
+const (
+	rawContractField        = 11
+	contractTypeField       = 1
+	contractParameterField  = 2
+	contractPermissionField = 5
+	anyValueField           = 2
+	contractOwnerField      = 1 // owner_address in most contract messages
+	addressLength           = 21
+)
+
+// ownerFields lists the contracts whose owner_address is not field 1.
+var ownerFields = map[ContractType]protowire.Number{
+	TransferAssetContract: 2,
+	AccountUpdateContract: 2,
+	SetAccountIDContract:  2,
+}
+
+// rawContract holds the fields of a signed contract that permission checks
+// depend on, decoded from raw_data_hex so they are covered by the signature.
+type rawContract struct {
+	Type         ContractType
+	Owner        Address
+	PermissionID int
+}
+
+func decodeRawContracts(raw []byte) ([]rawContract, error) {
+	var contracts []rawContract
+	err := walkFields(raw, func(num protowire.Number, typ protowire.Type, b []byte) error {
+		if num != rawContractField || typ != protowire.BytesType {
+			return nil
+		}
+		c, err := decodeRawContract(b)
+		if err != nil {
+			return err
+		}
+		contracts = append(contracts, c)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode raw_data_hex: %w", err)
+	}
+	return contracts, nil
+}
+
+func decodeRawContract(b []byte) (rawContract, error) {
+	var c rawContract
+	var param []byte
+	err := walkFields(b, func(num protowire.Number, typ protowire.Type, v []byte) error {
+		switch {
+		case num == contractTypeField && typ == protowire.VarintType:
+			n, _ := protowire.ConsumeVarint(v)
+			c.Type = ContractType(n)
+		case num == contractPermissionField && typ == protowire.VarintType:
+			n, _ := protowire.ConsumeVarint(v)
+			c.PermissionID = int(int32(n))
+		case num == contractParameterField && typ == protowire.BytesType:
+			param = v
+		}
+		return nil
+	})
+	if err != nil {
+		return c, err
+	}
+
+	// The owner field depends on the type, which may follow the parameter.
+	ownerField, ok := ownerFields[c.Type]
+	if !ok {
+		ownerField = contractOwnerField
+	}
+	err = walkFields(param, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != anyValueField || typ != protowire.BytesType {
+			return nil
+		}
+		return walkFields(value, func(num protowire.Number, typ protowire.Type, owner []byte) error {
+			if num != ownerField || typ != protowire.BytesType {
+				return nil
+			}
+			if len(owner) != addressLength {
+				return fmt.Errorf("invalid owner_address length %d", len(owner))
+			}
+			copy(c.Owner[:], owner)
+			return nil
+		})
+	})
+	return c, err
+}
+
+// walkFields calls fn for each top-level field of a protobuf message. For
+// varint fields v holds the encoded varint; for length-delimited fields the
+// payload.
+func walkFields(b []byte, fn func(num protowire.Number, typ protowire.Type, v []byte) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		var v []byte
+		switch typ {
+		case protowire.BytesType:
+			payload, m := protowire.ConsumeBytes(b)
+			if m < 0 {
+				return protowire.ParseError(m)
+			}
+			v, n = payload, m
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			v = b[:n]
+		}
+		if err := fn(num, typ, v); err != nil {
+			return err
+		}
+		b = b[n:]
+	}
+	return nil
+}
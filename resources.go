@@ -0,0 +1,246 @@
+package tron
+
+import (
+	"context"
+	"errors"
+)
+
+type Resource string
+
+const (
+	ResourceBandwidth Resource = "BANDWIDTH"
+	ResourceEnergy    Resource = "ENERGY"
+)
+
+type FreezeBalanceV2Req struct {
+	OwnerAddress  Address  `json:"owner_address"`
+	FrozenBalance int64    `json:"frozen_balance"`
+	Resource      Resource `json:"resource"`
+	PermissionID  int      `json:"Permission_id,omitempty"`
+	Visible       bool     `json:"visible,omitempty"`
+}
+
+// FreezeBalanceV2 stakes sun for bandwidth or energy (Stake 2.0).
+func (c *Client) FreezeBalanceV2(ctx context.Context, req FreezeBalanceV2Req) (Raw, error) {
+	if req.FrozenBalance <= 0 {
+		return nil, errors.New("frozen balance must be positive")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "freezebalancev2", req, &out)
+	return out, err
+}
+
+type UnfreezeBalanceV2Req struct {
+	OwnerAddress    Address  `json:"owner_address"`
+	UnfreezeBalance int64    `json:"unfreeze_balance"`
+	Resource        Resource `json:"resource"`
+	PermissionID    int      `json:"Permission_id,omitempty"`
+	Visible         bool     `json:"visible,omitempty"`
+}
+
+func (c *Client) UnfreezeBalanceV2(ctx context.Context, req UnfreezeBalanceV2Req) (Raw, error) {
+	if req.UnfreezeBalance <= 0 {
+		return nil, errors.New("unfreeze balance must be positive")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "unfreezebalancev2", req, &out)
+	return out, err
+}
+
+type OwnerReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+// WithdrawExpireUnfreeze withdraws unstaked TRX whose waiting period is over.
+func (c *Client) WithdrawExpireUnfreeze(ctx context.Context, owner Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "withdrawexpireunfreeze", OwnerReq{OwnerAddress: owner, Visible: c.visible}, &out)
+	return out, err
+}
+
+// CancelAllUnfreezeV2 cancels pending unstakes, re-staking the amounts still
+// waiting and withdrawing the expired ones.
+func (c *Client) CancelAllUnfreezeV2(ctx context.Context, owner Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "cancelallunfreezev2", OwnerReq{OwnerAddress: owner, Visible: c.visible}, &out)
+	return out, err
+}
+
+type DelegateResourceReq struct {
+	OwnerAddress    Address  `json:"owner_address"`
+	ReceiverAddress Address  `json:"receiver_address"`
+	Balance         int64    `json:"balance"`
+	Resource        Resource `json:"resource"`
+	Lock            bool     `json:"lock,omitempty"`
+	// LockPeriod is in blocks and only applies when Lock is set.
+	LockPeriod   int64 `json:"lock_period,omitempty"`
+	PermissionID int   `json:"Permission_id,omitempty"`
+	Visible      bool  `json:"visible,omitempty"`
+}
+
+func (c *Client) DelegateResource(ctx context.Context, req DelegateResourceReq) (Raw, error) {
+	if req.Balance <= 0 {
+		return nil, errors.New("balance must be positive")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "delegateresource", req, &out)
+	return out, err
+}
+
+type UndelegateResourceReq struct {
+	OwnerAddress    Address  `json:"owner_address"`
+	ReceiverAddress Address  `json:"receiver_address"`
+	Balance         int64    `json:"balance"`
+	Resource        Resource `json:"resource"`
+	PermissionID    int      `json:"Permission_id,omitempty"`
+	Visible         bool     `json:"visible,omitempty"`
+}
+
+func (c *Client) UndelegateResource(ctx context.Context, req UndelegateResourceReq) (Raw, error) {
+	if req.Balance <= 0 {
+		return nil, errors.New("balance must be positive")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "undelegateresource", req, &out)
+	return out, err
+}
+
+type GetDelegatedResourceReq struct {
+	FromAddress Address `json:"fromAddress"`
+	ToAddress   Address `json:"toAddress"`
+	Visible     bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) GetDelegatedResourceV2(ctx context.Context, from Address, to Address) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "getdelegatedresourcev2", GetDelegatedResourceReq{
+		FromAddress: from,
+		ToAddress:   to,
+		Visible:     c.visible,
+	}, &out)
+	return out, err
+}
+
+type AddressValueReq struct {
+	Value   Address `json:"value"`
+	Visible bool    `json:"visible,omitempty"`
+}
+
+type DelegatedResourceAccountIndex struct {
+	Account      Address   `json:"account"`
+	FromAccounts []Address `json:"fromAccounts"`
+	ToAccounts   []Address `json:"toAccounts"`
+}
+
+// GetDelegatedResourceAccountIndexV2 returns the accounts address delegates
+// resources to and receives them from.
+func (c *Client) GetDelegatedResourceAccountIndexV2(ctx context.Context, address Address) (*DelegatedResourceAccountIndex, error) {
+	var out DelegatedResourceAccountIndex
+	err := c.Call(ctx, "getdelegatedresourceaccountindexv2", AddressValueReq{Value: address, Visible: c.visible}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+type GetCanDelegatedMaxSizeReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	Type         int     `json:"type"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+type maxSizeResp struct {
+	MaxSize int64 `json:"max_size"`
+}
+
+// GetCanDelegatedMaxSize returns the most sun of staked TRX owner can
+// currently delegate for resource.
+func (c *Client) GetCanDelegatedMaxSize(ctx context.Context, owner Address, resource Resource) (int64, error) {
+	typ := 0
+	if resource == ResourceEnergy {
+		typ = 1
+	}
+
+	var out maxSizeResp
+	err := c.Call(ctx, "getcandelegatedmaxsize", GetCanDelegatedMaxSizeReq{
+		OwnerAddress: owner,
+		Type:         typ,
+		Visible:      c.visible,
+	}, &out)
+	return out.MaxSize, err
+}
+
+type unfreezeCountResp struct {
+	Count int64 `json:"count"`
+}
+
+// GetAvailableUnfreezeCount returns how many more unstake operations owner can
+// start while others are pending.
+func (c *Client) GetAvailableUnfreezeCount(ctx context.Context, owner Address) (int64, error) {
+	var out unfreezeCountResp
+	err := c.Call(ctx, "getavailableunfreezecount", OwnerReq{OwnerAddress: owner, Visible: c.visible}, &out)
+	return out.Count, err
+}
+
+type GetCanWithdrawUnfreezeAmountReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	Timestamp    int64   `json:"timestamp"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+type withdrawAmountResp struct {
+	Amount int64 `json:"amount"`
+}
+
+// GetCanWithdrawUnfreezeAmount returns the unstaked sun owner can withdraw at
+// timestamp, in milliseconds.
+func (c *Client) GetCanWithdrawUnfreezeAmount(ctx context.Context, owner Address, timestamp int64) (int64, error) {
+	var out withdrawAmountResp
+	err := c.Call(ctx, "getcanwithdrawunfreezeamount", GetCanWithdrawUnfreezeAmountReq{
+		OwnerAddress: owner,
+		Timestamp:    timestamp,
+		Visible:      c.visible,
+	}, &out)
+	return out.Amount, err
+}
+
+type pricesResp struct {
+	Prices string `json:"prices"`
+}
+
+// GetEnergyPrices returns the energy price history as reported by the node:
+// comma-separated "timestamp:sun" pairs.
+func (c *Client) GetEnergyPrices(ctx context.Context) (string, error) {
+	var out pricesResp
+	err := c.Call(ctx, "getenergyprices", nil, &out)
+	return out.Prices, err
+}
+
+// GetBandwidthPrices returns the bandwidth price history in the same format
+// as GetEnergyPrices.
+func (c *Client) GetBandwidthPrices(ctx context.Context) (string, error) {
+	var out pricesResp
+	err := c.Call(ctx, "getbandwidthprices", nil, &out)
+	return out.Prices, err
+}
+
+type burnTRXResp struct {
+	BurnTRXAmount int64 `json:"burnTrxAmount"`
+}
+
+// GetBurnTRX returns the total sun burned in fees since the burn proposal.
+func (c *Client) GetBurnTRX(ctx context.Context) (int64, error) {
+	var out burnTRXResp
+	err := c.Call(ctx, "getburntrx", nil, &out)
+	return out.BurnTRXAmount, err
+}
@@ -0,0 +1,309 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+)
+
+type Network string
+
+const (
+	NetworkMainnet Network = "mainnet"
+	NetworkNile    Network = "nile"
+	NetworkShasta  Network = "shasta"
+)
+
+const defaultTokenTTL = 24 * time.Hour
+
+type TokenInfo struct {
+	Address  Address `json:"address"`
+	Name     string  `json:"name"`
+	Symbol   string  `json:"symbol"`
+	Decimals uint8   `json:"decimals"`
+}
+
+func (t TokenInfo) Amount(baseUnits *big.Int) Amount {
+	return NewAmount(baseUnits, t.Decimals)
+}
+
+func (t TokenInfo) ParseAmount(s string) (Amount, error) {
+	return ParseAmount(s, t.Decimals)
+}
+
+// Format renders baseUnits as a human amount followed by the symbol, e.g.
+// "12.500000 USDT".
+func (t TokenInfo) Format(baseUnits *big.Int) string {
+	if t.Symbol == "" {
+		return t.Amount(baseUnits).String()
+	}
+	return t.Amount(baseUnits).String() + " " + t.Symbol
+}
+
+var knownTokens = map[Network][]TokenInfo{
+	NetworkMainnet: {
+		{Address: MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"), Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+		{Address: MustParseAddress("TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8"), Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		{Address: MustParseAddress("TXDk8mbtRbXeYuMNS83CfKPaYYT8XWv9Hz"), Name: "Decentralized USD", Symbol: "USDD", Decimals: 18},
+		{Address: MustParseAddress("TUpMhErZL2fhh4sVNULAbNKLokS4GjC1F4"), Name: "TrueUSD", Symbol: "TUSD", Decimals: 18},
+		{Address: MustParseAddress("TNUC9Qb1rRpS5CbWLmNMxXBjyFoydXjWFR"), Name: "Wrapped TRX", Symbol: "WTRX", Decimals: 6},
+		{Address: MustParseAddress("TAFjULxiVgT4qWk6UZwjqwZXTSaGaqnVp4"), Name: "BitTorrent", Symbol: "BTT", Decimals: 18},
+		{Address: MustParseAddress("TCFLL5dx5ZJdKnWuesXxi1VPwjLVmWZZy9"), Name: "JUST", Symbol: "JST", Decimals: 18},
+		{Address: MustParseAddress("TSSMHYeV2uE9qYH95DqyoCuNCzEL1NvU3S"), Name: "SUN", Symbol: "SUN", Decimals: 18},
+		{Address: MustParseAddress("TLa2f6VPqDgRE67v1736s7bJ8Ray5wYjU7"), Name: "WINK", Symbol: "WIN", Decimals: 6},
+	},
+	NetworkNile: {
+		{Address: MustParseAddress("TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf"), Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+	},
+	NetworkShasta: {
+		{Address: MustParseAddress("TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs"), Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+	},
+}
+
+// KnownTokens returns the built-in token table. Nile and Shasta only list
+// USDT: the other mainnet tokens have no canonical testnet deployments, so
+// register the ones you use on a testnet with TokenRegistry.Register.
+func KnownTokens(network Network) []TokenInfo {
+	return append([]TokenInfo(nil), knownTokens[network]...)
+}
+
+// TokenStore persists fetched metadata across restarts, e.g. in a database.
+type TokenStore interface {
+	LoadToken(ctx context.Context, address Address) (TokenInfo, bool, error)
+	SaveToken(ctx context.Context, info TokenInfo) error
+}
+
+type TokenRegistryOption func(*TokenRegistry)
+
+// WithTokenNetwork selects the built-in token list; the default is mainnet.
+func WithTokenNetwork(network Network) TokenRegistryOption {
+	return func(r *TokenRegistry) { r.network = network }
+}
+
+// WithTokenTTL sets how long fetched metadata stays in memory; 0 keeps it
+// forever.
+func WithTokenTTL(ttl time.Duration) TokenRegistryOption {
+	return func(r *TokenRegistry) { r.ttl = ttl }
+}
+
+func WithTokenStore(store TokenStore) TokenRegistryOption {
+	return func(r *TokenRegistry) { r.store = store }
+}
+
+type tokenEntry struct {
+	info    TokenInfo
+	expires time.Time
+}
+
+type TokenRegistry struct {
+	c       *Client
+	network Network
+	ttl     time.Duration
+	store   TokenStore
+
+	mu      sync.RWMutex
+	builtin map[Address]TokenInfo
+	entries map[Address]tokenEntry
+	flights flightGroup
+}
+
+func (c *Client) NewTokenRegistry(opts ...TokenRegistryOption) *TokenRegistry {
+	r := &TokenRegistry{
+		c:       c,
+		network: NetworkMainnet,
+		ttl:     defaultTokenTTL,
+		builtin: make(map[Address]TokenInfo),
+		entries: make(map[Address]tokenEntry),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	for _, info := range knownTokens[r.network] {
+		r.builtin[info.Address] = info
+	}
+	return r
+}
+
+// Register pins metadata for a token; pinned entries never expire.
+func (r *TokenRegistry) Register(info TokenInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.builtin[info.Address] = info
+}
+
+func (r *TokenRegistry) Invalidate(address Address) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.entries, address)
+}
+
+// Get returns token metadata from, in order, pinned entries, the in-memory
+// cache, the persistent store and finally the node. Concurrent lookups of the
+// same token share one store and node round trip.
+func (r *TokenRegistry) Get(ctx context.Context, address Address) (TokenInfo, error) {
+	if info, ok := r.cached(address); ok {
+		return info, nil
+	}
+
+	raw, err := r.flights.do(ctx, address.String(), func(ctx context.Context) ([]byte, error) {
+		info, err := r.load(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		r.remember(info)
+		return json.Marshal(info)
+	})
+	if err != nil {
+		return TokenInfo{}, err
+	}
+
+	var info TokenInfo
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return TokenInfo{}, err
+	}
+	return info, nil
+}
+
+func (r *TokenRegistry) load(ctx context.Context, address Address) (TokenInfo, error) {
+	if r.store != nil {
+		info, ok, err := r.store.LoadToken(ctx, address)
+		if err != nil {
+			return TokenInfo{}, fmt.Errorf("load token %s: %w", address, err)
+		}
+		if ok {
+			return info, nil
+		}
+	}
+
+	info, err := r.fetch(ctx, address)
+	if err != nil {
+		return TokenInfo{}, err
+	}
+
+	if r.store != nil {
+		if err := r.store.SaveToken(ctx, info); err != nil {
+			return TokenInfo{}, fmt.Errorf("save token %s: %w", address, err)
+		}
+	}
+	return info, nil
+}
+
+func (r *TokenRegistry) cached(address Address) (TokenInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if info, ok := r.builtin[address]; ok {
+		return info, true
+	}
+	e, ok := r.entries[address]
+	if !ok || (!e.expires.IsZero() && time.Now().After(e.expires)) {
+		return TokenInfo{}, false
+	}
+	return e.info, true
+}
+
+func (r *TokenRegistry) remember(info TokenInfo) {
+	e := tokenEntry{info: info}
+	if r.ttl > 0 {
+		e.expires = time.Now().Add(r.ttl)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[info.Address] = e
+}
+
+func (r *TokenRegistry) fetch(ctx context.Context, address Address) (TokenInfo, error) {
+	t := r.c.NewTRC20(address)
+
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("fetch decimals of %s: %w", address, err)
+	}
+	name, err := t.Name(ctx)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("fetch name of %s: %w", address, err)
+	}
+	symbol, err := t.Symbol(ctx)
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("fetch symbol of %s: %w", address, err)
+	}
+
+	return TokenInfo{
+		Address:  address,
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: decimals,
+	}, nil
+}
+
+// FileTokenStore keeps token metadata in a JSON file. The file is read once
+// and then served from memory, so it must not be shared with another writer.
+type FileTokenStore struct {
+	path string
+
+	mu     sync.Mutex
+	tokens map[string]TokenInfo
+}
+
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) LoadToken(_ context.Context, address Address) (TokenInfo, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.read(); err != nil {
+		return TokenInfo{}, false, err
+	}
+	info, ok := s.tokens[address.String()]
+	return info, ok, nil
+}
+
+func (s *FileTokenStore) SaveToken(_ context.Context, info TokenInfo) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.read(); err != nil {
+		return err
+	}
+	s.tokens[info.Address.String()] = info
+
+	b, err := json.MarshalIndent(s.tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+func (s *FileTokenStore) read() error {
+	if s.tokens != nil {
+		return nil
+	}
+
+	tokens := make(map[string]TokenInfo)
+	b, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err == nil {
+		if err := json.Unmarshal(b, &tokens); err != nil {
+			return fmt.Errorf("decode %s: %w", s.path, err)
+		}
+	}
+	s.tokens = tokens
+	return nil
+}
@@ -0,0 +1,106 @@
+package tron_test
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// countCalls counts node calls; the first one waits for release so that
+// concurrent callers can pile up behind it.
+func countCalls(calls *atomic.Int64, release <-chan struct{}) tron.Interceptor {
+	return func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			if calls.Add(1) == 1 && release != nil {
+				<-release
+			}
+			return next(ctx, path, req, out)
+		}
+	}
+}
+
+func TestTokenRegistry(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	addr := deploy(t, node, mockTRC20(false), nil)
+	var calls atomic.Int64
+	c := node.Client(tron.WithInterceptor(countCalls(&calls, nil)))
+	store := tron.NewFileTokenStore(filepath.Join(t.TempDir(), "tokens.json"))
+
+	reg := c.NewTokenRegistry(tron.WithTokenStore(store))
+	usdt, err := reg.Get(ctx, tron.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"))
+	if err != nil || usdt.Symbol != "USDT" || usdt.Decimals != 6 {
+		t.Fatalf("built-in USDT = %+v, %v", usdt, err)
+	}
+	if n := calls.Load(); n != 0 {
+		t.Fatalf("built-in lookup made %d node calls", n)
+	}
+
+	want := tron.TokenInfo{Address: addr, Name: "Mock Token", Symbol: "MOCK", Decimals: 6}
+	for range 2 {
+		info, err := reg.Get(ctx, addr)
+		if err != nil || info != want {
+			t.Fatalf("Get = %+v, %v; want %+v", info, err, want)
+		}
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("node calls = %d, want 3 (decimals, name, symbol once)", n)
+	}
+
+	// A fresh registry finds the token in the store.
+	info, err := c.NewTokenRegistry(tron.WithTokenStore(store)).Get(ctx, addr)
+	if err != nil || info != want {
+		t.Fatalf("Get from store = %+v, %v; want %+v", info, err, want)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("node calls = %d after store hit, want 3", n)
+	}
+
+	if got := want.Format(big.NewInt(12_500_000)); got != "12.500000 MOCK" {
+		t.Fatalf("Format = %q", got)
+	}
+}
+
+func TestTokenRegistryCoalesces(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	addr := deploy(t, node, mockTRC20(false), nil)
+	var calls atomic.Int64
+	release := make(chan struct{})
+	reg := node.Client(tron.WithInterceptor(countCalls(&calls, release))).NewTokenRegistry()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := reg.Get(ctx, addr)
+			errs <- err
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("node calls = %d, want 3", n)
+	}
+}
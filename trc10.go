@@ -0,0 +1,266 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+type AssetIssue struct {
+	ID           string  `json:"id"`
+	OwnerAddress Address `json:"owner_address"`
+	Name         string  `json:"name"`
+	Abbr         string  `json:"abbr"`
+	TotalSupply  int64   `json:"total_supply"`
+	TrxNum       int64   `json:"trx_num"`
+	Num          int64   `json:"num"`
+	Precision    uint8   `json:"precision"`
+	StartTime    int64   `json:"start_time"`
+	EndTime      int64   `json:"end_time"`
+	Description  string  `json:"description"`
+	URL          string  `json:"url"`
+}
+
+func (a AssetIssue) Amount(baseUnits *big.Int) Amount {
+	return NewAmount(baseUnits, a.Precision)
+}
+
+func (a AssetIssue) ParseAmount(s string) (Amount, error) {
+	return ParseAmount(s, a.Precision)
+}
+
+type GetAssetIssueByIDReq struct {
+	Value   string `json:"value"`
+	Visible bool   `json:"visible,omitempty"`
+}
+
+func (c *Client) GetAssetIssueByID(ctx context.Context, tokenID string) (*AssetIssue, error) {
+	var out AssetIssue
+	err := c.Call(ctx, "getassetissuebyid", GetAssetIssueByIDReq{
+		Value:   tokenID,
+		Visible: c.visible,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.ID == "" {
+		return nil, fmt.Errorf("asset %s not found", tokenID)
+	}
+	return &out, nil
+}
+
+type assetIssueListResp struct {
+	AssetIssue []AssetIssue `json:"assetIssue"`
+}
+
+type VisibleReq struct {
+	Visible bool `json:"visible,omitempty"`
+}
+
+func (c *Client) GetAssetIssueList(ctx context.Context) ([]AssetIssue, error) {
+	var out assetIssueListResp
+	if err := c.Call(ctx, "getassetissuelist", VisibleReq{Visible: c.visible}, &out); err != nil {
+		return nil, err
+	}
+	return out.AssetIssue, nil
+}
+
+type GetPaginatedAssetIssueListReq struct {
+	Offset  int64 `json:"offset"`
+	Limit   int64 `json:"limit"`
+	Visible bool  `json:"visible,omitempty"`
+}
+
+func (c *Client) GetPaginatedAssetIssueList(ctx context.Context, offset int64, limit int64) ([]AssetIssue, error) {
+	var out assetIssueListResp
+	err := c.Call(ctx, "getpaginatedassetissuelist", GetPaginatedAssetIssueListReq{
+		Offset:  offset,
+		Limit:   limit,
+		Visible: c.visible,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.AssetIssue, nil
+}
+
+type TransferAssetReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	ToAddress    Address `json:"to_address"`
+	AssetName    string  `json:"asset_name"`
+	Amount       int64   `json:"amount"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+func (c *Client) TransferAsset(ctx context.Context, req TransferAssetReq) (Raw, error) {
+	var out Raw
+	err := c.Call(ctx, "transferasset", req, &out)
+	return out, err
+}
+
+// BuildTransferTRC10Tx builds a transfer of amount of the TRC10 token. The
+// amount is rescaled to the asset's precision and rejected if it has more
+// decimal places than the asset supports.
+func (c *Client) BuildTransferTRC10Tx(ctx context.Context, from Address, to Address, tokenID string, amount Amount, opts ...TxOption) (Raw, error) {
+	asset, err := c.GetAssetIssueByID(ctx, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	units, err := amount.Rescale(asset.Precision)
+	if err != nil {
+		return nil, fmt.Errorf("asset %s has %d decimals: %w", tokenID, asset.Precision, err)
+	}
+
+	v := units.BaseUnits()
+	if v.Sign() <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	if !v.IsInt64() {
+		return nil, errors.New("amount overflows int64")
+	}
+
+	return c.TransferAsset(ctx, TransferAssetReq{
+		OwnerAddress: from,
+		ToAddress:    to,
+		AssetName:    tokenID,
+		Amount:       v.Int64(),
+		PermissionID: applyTxOptions(opts).permissionID,
+		Visible:      c.visible,
+	})
+}
+
+type getAccountAssetsResp struct {
+	AssetV2 []struct {
+		Key   string `json:"key"`
+		Value int64  `json:"value"`
+	} `json:"assetV2"`
+}
+
+// TRC10Balances returns the account's TRC10 balances in base units keyed by
+// token id.
+func (c *Client) TRC10Balances(ctx context.Context, address Address) (map[string]*big.Int, error) {
+	raw, err := c.GetAccount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp getAccountAssetsResp
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*big.Int, len(resp.AssetV2))
+	for _, a := range resp.AssetV2 {
+		balances[a.Key] = big.NewInt(a.Value)
+	}
+	return balances, nil
+}
+
+func (c *Client) TRC10BalanceOf(ctx context.Context, address Address, tokenID string) (*big.Int, error) {
+	balances, err := c.TRC10Balances(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := balances[tokenID]; ok {
+		return b, nil
+	}
+	return big.NewInt(0), nil
+}
+
+// TransferTRC10 sends amount, a decimal string such as "1.5", of the TRC10
+// token.
+func (c *Client) TransferTRC10(ctx context.Context, tokenID string, to string, amount string, privateKey string) (string, error) {
+	from, err := PrivateKeyHexToAddress(privateKey)
+	if err != nil {
+		return "", err
+	}
+	toAddr, err := ParseAddress(to)
+	if err != nil {
+		return "", err
+	}
+
+	var value Amount
+	if err := value.UnmarshalText([]byte(amount)); err != nil {
+		return "", err
+	}
+
+	tx, err := c.BuildTransferTRC10Tx(ctx, from, toAddr, tokenID, value)
+	if err != nil {
+		return "", err
+	}
+
+	return c.signAndBroadcast(ctx, tx, privateKey,
+		"kind", "trc10", "token", tokenID, "from", from, "to", toAddr, "amount", value)
+}
+
+type GetAssetIssueByNameReq struct {
+	Value   string `json:"value"`
+	Visible bool   `json:"visible,omitempty"`
+}
+
+// GetAssetIssueByName fails when several assets share the name; use
+// GetAssetIssueListByName for those.
+func (c *Client) GetAssetIssueByName(ctx context.Context, name string) (*AssetIssue, error) {
+	var out AssetIssue
+	err := c.Call(ctx, "getassetissuebyname", GetAssetIssueByNameReq{
+		Value:   name,
+		Visible: c.visible,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	if out.ID == "" {
+		return nil, fmt.Errorf("asset %s not found", name)
+	}
+	return &out, nil
+}
+
+func (c *Client) GetAssetIssueListByName(ctx context.Context, name string) ([]AssetIssue, error) {
+	var out assetIssueListResp
+	err := c.Call(ctx, "getassetissuelistbyname", GetAssetIssueByNameReq{
+		Value:   name,
+		Visible: c.visible,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.AssetIssue, nil
+}
+
+// GetAssetIssueByAccount returns the assets issued by address.
+func (c *Client) GetAssetIssueByAccount(ctx context.Context, address Address) ([]AssetIssue, error) {
+	var out assetIssueListResp
+	err := c.Call(ctx, "getassetissuebyaccount", AddressReq{
+		Address: address,
+		Visible: c.visible,
+	}, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out.AssetIssue, nil
+}
+
+type ParticipateAssetIssueReq struct {
+	OwnerAddress Address `json:"owner_address"`
+	// ToAddress is the issuer of the asset.
+	ToAddress    Address `json:"to_address"`
+	AssetName    string  `json:"asset_name"`
+	Amount       int64   `json:"amount"`
+	PermissionID int     `json:"Permission_id,omitempty"`
+	Visible      bool    `json:"visible,omitempty"`
+}
+
+// ParticipateAssetIssue buys asset tokens during the issue, paying Amount sun.
+func (c *Client) ParticipateAssetIssue(ctx context.Context, req ParticipateAssetIssueReq) (Raw, error) {
+	if req.Amount <= 0 {
+		return nil, errors.New("amount must be positive")
+	}
+	req.Visible = c.visible
+
+	var out Raw
+	err := c.Call(ctx, "participateassetissue", req, &out)
+	return out, err
+}
@@ -0,0 +1,51 @@
+package tron_test
+
+import (
+	"context"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestTransferTRC10(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	issuer, bob := trontest.NewKey("issuer"), trontest.NewKey("bob")
+	node.Fund(issuer.Address, 100_000_000)
+	id := node.IssueAsset(tron.AssetIssue{
+		OwnerAddress: issuer.Address,
+		Name:         "Gold",
+		Abbr:         "GLD",
+		TotalSupply:  1_000_000,
+		Precision:    2,
+	})
+	c := node.Client()
+
+	asset, err := c.GetAssetIssueByID(ctx, id)
+	if err != nil || asset.Precision != 2 || asset.Name != "Gold" {
+		t.Fatalf("asset = %+v, %v", asset, err)
+	}
+	if _, err := c.GetAssetIssueByID(ctx, "999"); err == nil {
+		t.Fatal("unknown asset was found")
+	}
+
+	// "1.5" with precision 2 is 150 base units.
+	if _, err := c.TransferTRC10(ctx, id, bob.Address.String(), "1.5", issuer.PrivateKey); err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	got, err := c.TRC10BalanceOf(ctx, bob.Address, id)
+	if err != nil || got.Int64() != 150 {
+		t.Fatalf("bob balance = %v, %v; want 150", got, err)
+	}
+	balances, err := c.TRC10Balances(ctx, issuer.Address)
+	if err != nil || balances[id].Int64() != 999_850 {
+		t.Fatalf("issuer balances = %v, %v; want %s: 999850", balances, err, id)
+	}
+
+	if _, err := c.TransferTRC10(ctx, id, bob.Address.String(), "0.001", issuer.PrivateKey); err == nil {
+		t.Fatal("transfer with more decimals than the asset succeeded")
+	}
+}
@@ -0,0 +1,293 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const trc1155ABIJSON = `[
+{"inputs":[{"name":"account","type":"address"},{"name":"id","type":"uint256"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"accounts","type":"address[]"},{"name":"ids","type":"uint256[]"}],"name":"balanceOfBatch","outputs":[{"name":"","type":"uint256[]"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"id","type":"uint256"}],"name":"uri","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"account","type":"address"},{"name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"id","type":"uint256"},{"name":"amount","type":"uint256"},{"name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"ids","type":"uint256[]"},{"name":"amounts","type":"uint256[]"},{"name":"data","type":"bytes"}],"name":"safeBatchTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"id","type":"uint256"},{"indexed":false,"name":"value","type":"uint256"}],"name":"TransferSingle","type":"event"},
+{"anonymous":false,"inputs":[{"indexed":true,"name":"operator","type":"address"},{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"ids","type":"uint256[]"},{"indexed":false,"name":"values","type":"uint256[]"}],"name":"TransferBatch","type":"event"}
+]`
+
+var trc1155ABI = mustParseABI(trc1155ABIJSON)
+
+type TRC1155 struct {
+	c        *Client
+	contract Address
+}
+
+func (c *Client) NewTRC1155(contract Address) *TRC1155 {
+	return &TRC1155{c: c, contract: contract}
+}
+
+func (t *TRC1155) BalanceOf(ctx context.Context, owner Address, id *big.Int) (*big.Int, error) {
+	out, err := t.c.callABI(ctx, owner, t.contract, &trc1155ABI, "balanceOf", owner.EVM(), id)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+// BalanceOfBatch returns the balance of owners[i] in token ids[i].
+func (t *TRC1155) BalanceOfBatch(ctx context.Context, owners []Address, ids []*big.Int) ([]*big.Int, error) {
+	if len(owners) != len(ids) {
+		return nil, fmt.Errorf("owners and ids length mismatch: %d != %d", len(owners), len(ids))
+	}
+
+	accounts := make([]common.Address, len(owners))
+	for i, o := range owners {
+		accounts[i] = o.EVM()
+	}
+
+	out, err := t.c.callABI(ctx, ownerFromAddressStub, t.contract, &trc1155ABI, "balanceOfBatch", accounts, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := out[0].([]*big.Int)
+	if len(balances) != len(ids) {
+		return nil, fmt.Errorf("balanceOfBatch returned %d balances for %d ids", len(balances), len(ids))
+	}
+	return balances, nil
+}
+
+// URI returns the raw metadata URI; see ExpandTRC1155URI for the {id}
+// placeholder.
+func (t *TRC1155) URI(ctx context.Context, id *big.Int) (string, error) {
+	out, err := t.c.callABI(ctx, ownerFromAddressStub, t.contract, &trc1155ABI, "uri", id)
+	if err != nil {
+		return "", err
+	}
+	return out[0].(string), nil
+}
+
+// ExpandTRC1155URI substitutes {id} with the zero-padded lowercase hex token id.
+func ExpandTRC1155URI(uri string, id *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", id))
+}
+
+func (t *TRC1155) IsApprovedForAll(ctx context.Context, owner Address, operator Address) (bool, error) {
+	out, err := t.c.callABI(ctx, owner, t.contract, &trc1155ABI, "isApprovedForAll", owner.EVM(), operator.EVM())
+	if err != nil {
+		return false, err
+	}
+	return out[0].(bool), nil
+}
+
+func (t *TRC1155) SupportsInterface(ctx context.Context, interfaceID [4]byte) (bool, error) {
+	return supportsInterface(ctx, t.c, t.contract, interfaceID)
+}
+
+func (t *TRC1155) BuildSafeTransferFromTx(
+	ctx context.Context,
+	from Address,
+	to Address,
+	id *big.Int,
+	amount *big.Int,
+	data []byte,
+	feeLimit int64,
+) (json.RawMessage, error) {
+	if data == nil {
+		data = []byte{}
+	}
+	return t.c.buildABITx(ctx, from, t.contract, &trc1155ABI, "safeTransferFrom", feeLimit, from.EVM(), to.EVM(), id, amount, data)
+}
+
+func (t *TRC1155) BuildSafeBatchTransferFromTx(
+	ctx context.Context,
+	from Address,
+	to Address,
+	ids []*big.Int,
+	amounts []*big.Int,
+	data []byte,
+	feeLimit int64,
+) (json.RawMessage, error) {
+	if len(ids) != len(amounts) {
+		return nil, fmt.Errorf("ids and amounts length mismatch: %d != %d", len(ids), len(amounts))
+	}
+	if data == nil {
+		data = []byte{}
+	}
+	return t.c.buildABITx(ctx, from, t.contract, &trc1155ABI, "safeBatchTransferFrom", feeLimit, from.EVM(), to.EVM(), ids, amounts, data)
+}
+
+func (t *TRC1155) BuildSetApprovalForAllTx(
+	ctx context.Context,
+	owner Address,
+	operator Address,
+	approved bool,
+	feeLimit int64,
+) (json.RawMessage, error) {
+	return t.c.buildABITx(ctx, owner, t.contract, &trc1155ABI, "setApprovalForAll", feeLimit, operator.EVM(), approved)
+}
+
+// TransactionLog is an entry of the "log" array in gettransactioninfobyid.
+// Address is the emitting contract; the node reports it without the 0x41
+// prefix.
+type TransactionLog struct {
+	Address Address  `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+func (l TransactionLog) topicHashes() ([]common.Hash, error) {
+	hashes := make([]common.Hash, len(l.Topics))
+	for i, t := range l.Topics {
+		b, err := hex.DecodeString(strings.TrimPrefix(t, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("decode topic[%d]: %w", i, err)
+		}
+		hashes[i] = common.BytesToHash(b)
+	}
+	return hashes, nil
+}
+
+func (l TransactionLog) data() ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(l.Data, "0x"))
+}
+
+type transactionLogsResp struct {
+	Log []TransactionLog `json:"log"`
+}
+
+func (c *Client) GetTransactionLogs(ctx context.Context, txID string) ([]TransactionLog, error) {
+	raw, err := c.GetTransactionInfoByID(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out transactionLogsResp
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out.Log, nil
+}
+
+var ErrLogMismatch = errors.New("log does not match event")
+
+type TRC1155TransferSingle struct {
+	Contract Address
+	Operator Address
+	From     Address
+	To       Address
+	ID       *big.Int
+	Value    *big.Int
+}
+
+type TRC1155TransferBatch struct {
+	Contract Address
+	Operator Address
+	From     Address
+	To       Address
+	IDs      []*big.Int
+	Values   []*big.Int
+}
+
+// decodeTransferLog checks the event signature, returns the three indexed
+// addresses shared by TransferSingle and TransferBatch and the unpacked data.
+func decodeTransferLog(l TransactionLog, event string) ([3]Address, []any, error) {
+	var addrs [3]Address
+	ev := trc1155ABI.Events[event]
+
+	topics, err := l.topicHashes()
+	if err != nil {
+		return addrs, nil, err
+	}
+	if len(topics) != 4 || topics[0] != ev.ID {
+		return addrs, nil, ErrLogMismatch
+	}
+	for i := range addrs {
+		addrs[i] = AddressFromEVM(common.BytesToAddress(topics[i+1].Bytes()))
+	}
+
+	data, err := l.data()
+	if err != nil {
+		return addrs, nil, fmt.Errorf("decode data: %w", err)
+	}
+	values, err := ev.Inputs.NonIndexed().Unpack(data)
+	if err != nil {
+		return addrs, nil, fmt.Errorf("unpack %s: %w", event, err)
+	}
+	return addrs, values, nil
+}
+
+func DecodeTRC1155TransferSingle(l TransactionLog) (*TRC1155TransferSingle, error) {
+	addrs, values, err := decodeTransferLog(l, "TransferSingle")
+	if err != nil {
+		return nil, err
+	}
+	return &TRC1155TransferSingle{
+		Contract: l.Address,
+		Operator: addrs[0],
+		From:     addrs[1],
+		To:       addrs[2],
+		ID:       values[0].(*big.Int),
+		Value:    values[1].(*big.Int),
+	}, nil
+}
+
+func DecodeTRC1155TransferBatch(l TransactionLog) (*TRC1155TransferBatch, error) {
+	addrs, values, err := decodeTransferLog(l, "TransferBatch")
+	if err != nil {
+		return nil, err
+	}
+	return &TRC1155TransferBatch{
+		Contract: l.Address,
+		Operator: addrs[0],
+		From:     addrs[1],
+		To:       addrs[2],
+		IDs:      values[0].([]*big.Int),
+		Values:   values[1].([]*big.Int),
+	}, nil
+}
+
+// TransferEvents decodes the TransferSingle and TransferBatch events this
+// contract emitted in the given transaction.
+func (t *TRC1155) TransferEvents(ctx context.Context, txID string) ([]TRC1155TransferSingle, []TRC1155TransferBatch, error) {
+	logs, err := t.c.GetTransactionLogs(ctx, txID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var singles []TRC1155TransferSingle
+	var batches []TRC1155TransferBatch
+	for i, l := range logs {
+		if l.Address != t.contract {
+			continue
+		}
+
+		single, err := DecodeTRC1155TransferSingle(l)
+		if err == nil {
+			singles = append(singles, *single)
+			continue
+		}
+		if !errors.Is(err, ErrLogMismatch) {
+			return nil, nil, fmt.Errorf("log[%d]: %w", i, err)
+		}
+
+		batch, err := DecodeTRC1155TransferBatch(l)
+		if err == nil {
+			batches = append(batches, *batch)
+			continue
+		}
+		if !errors.Is(err, ErrLogMismatch) {
+			return nil, nil, fmt.Errorf("log[%d]: %w", i, err)
+		}
+	}
+	return singles, batches, nil
+}
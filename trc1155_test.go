@@ -0,0 +1,92 @@
+package tron_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestTRC1155(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	alice, bob, carol := trontest.NewKey("alice"), trontest.NewKey("bob"), trontest.NewKey("carol")
+	node.Fund(alice.Address, 100_000_000)
+	node.Fund(bob.Address, 100_000_000)
+	addr := deploy(t, node, mockTRC1155(), map[common.Hash]common.Hash{
+		multiSlot(alice.Address, 1): common.BigToHash(big.NewInt(100)),
+		multiSlot(alice.Address, 2): common.BigToHash(big.NewInt(50)),
+	})
+	c := node.Client()
+	token := c.NewTRC1155(addr)
+
+	uri, err := token.URI(ctx, big.NewInt(1))
+	if err != nil || tron.ExpandTRC1155URI(uri, big.NewInt(1)) != "ipfs://0000000000000000000000000000000000000000000000000000000000000001.json" {
+		t.Fatalf("uri = %q, %v", uri, err)
+	}
+
+	tx, err := token.BuildSafeTransferFromTx(ctx, alice.Address, bob.Address, big.NewInt(1), big.NewInt(30), nil, feeLimit)
+	if err != nil {
+		t.Fatalf("build safeTransferFrom: %v", err)
+	}
+	txID := send(t, c, tx, alice)
+	singles, batches, err := token.TransferEvents(ctx, txID)
+	if err != nil || len(singles) != 1 || len(batches) != 0 {
+		t.Fatalf("events = %v, %v, %v; want one TransferSingle", singles, batches, err)
+	}
+	if s := singles[0]; s.Contract != addr || s.Operator != alice.Address || s.From != alice.Address || s.To != bob.Address || s.ID.Int64() != 1 || s.Value.Int64() != 30 {
+		t.Fatalf("TransferSingle = %+v", s)
+	}
+
+	// carol moves alice's tokens as her operator.
+	node.Fund(carol.Address, 100_000_000)
+	tx, err = token.BuildSetApprovalForAllTx(ctx, alice.Address, carol.Address, true, feeLimit)
+	if err != nil {
+		t.Fatalf("build setApprovalForAll: %v", err)
+	}
+	send(t, c, tx, alice)
+	if ok, err := token.IsApprovedForAll(ctx, alice.Address, carol.Address); err != nil || !ok {
+		t.Fatalf("isApprovedForAll = %v, %v; want true", ok, err)
+	}
+
+	ids, amounts := []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(20), big.NewInt(50)}
+	tx, err = token.BuildSafeBatchTransferFromTx(ctx, alice.Address, bob.Address, ids, amounts, nil, feeLimit)
+	if err != nil {
+		t.Fatalf("build safeBatchTransferFrom: %v", err)
+	}
+	txID = send(t, c, tx, alice)
+	_, batches, err = token.TransferEvents(ctx, txID)
+	if err != nil || len(batches) != 1 {
+		t.Fatalf("batches = %v, %v; want one TransferBatch", batches, err)
+	}
+	if b := batches[0]; len(b.IDs) != 2 || b.IDs[1].Int64() != 2 || b.Values[0].Int64() != 20 || b.Values[1].Int64() != 50 {
+		t.Fatalf("TransferBatch = %+v", b)
+	}
+
+	balances, err := token.BalanceOfBatch(ctx,
+		[]tron.Address{alice.Address, bob.Address, bob.Address},
+		[]*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(2)})
+	if err != nil {
+		t.Fatalf("balanceOfBatch: %v", err)
+	}
+	for i, want := range []int64{50, 50, 50} {
+		if balances[i].Int64() != want {
+			t.Fatalf("balances = %v, want [50 50 50]", balances)
+		}
+	}
+
+	// More than alice has left reverts.
+	tx, err = token.BuildSafeTransferFromTx(ctx, alice.Address, bob.Address, big.NewInt(2), big.NewInt(1), nil, feeLimit)
+	if err != nil {
+		t.Fatalf("build safeTransferFrom: %v", err)
+	}
+	txID = send(t, c, tx, alice)
+	if status, err := c.GetTransactionStatus(ctx, txID); err != nil || status == tron.TxStatusSuccess {
+		t.Fatalf("status = %q, %v; want a failure", status, err)
+	}
+}
@@ -13,58 +13,46 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-const (
-	ownerFromAddressStub = "TTyNBH7UDfxY1wqyjq9CsTgYM9p5KnNB3b"
-)
+var ownerFromAddressStub = MustParseAddress("TTyNBH7UDfxY1wqyjq9CsTgYM9p5KnNB3b")
 
 type TRC20 struct {
 	c        *Client
-	contract string
+	contract Address
 }
 
-func (c *Client) NewTRC20(contract string) *TRC20 {
+func (c *Client) NewTRC20(contract Address) *TRC20 {
 	return &TRC20{c: c, contract: contract}
 }
 
 type TriggerConstResult struct {
 	Result struct {
-		Result bool `json:"result"`
+		Result  bool   `json:"result"`
+		Code    string `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
 	} `json:"result"`
 	ConstantResult []string `json:"constant_result"`
 	Message        string   `json:"message,omitempty"`
 }
 
-func (t *TRC20) BalanceOf(ctx context.Context, owner string) (*big.Int, error) {
-	param, err := ABIEncodeAddressParam(owner)
+func (t *TRC20) BalanceOf(ctx context.Context, owner Address) (*big.Int, error) {
+	ret, err := t.c.constantCall(ctx, owner, t.contract, "balanceOf(address)", ABIEncodeAddress(owner))
 	if err != nil {
 		return nil, err
 	}
+	return new(big.Int).SetBytes(ret), nil
+}
 
-	raw, err := t.c.TriggerConstantContract(ctx, TriggerConstantContractReq{
-		OwnerAddress:    owner,
-		ContractAddress: t.contract,
-		Function:        "balanceOf(address)",
-		Parameter:       param,
-		Visible:         t.c.visible,
-	})
+func (t *TRC20) Allowance(ctx context.Context, owner Address, spender Address) (*big.Int, error) {
+	param, err := ABIConcatParams(ABIEncodeAddress(owner), ABIEncodeAddress(spender))
 	if err != nil {
 		return nil, err
 	}
 
-	var out TriggerConstResult
-	if err := json.Unmarshal(raw, &out); err != nil {
+	ret, err := t.c.constantCall(ctx, owner, t.contract, "allowance(address,address)", param)
+	if err != nil {
 		return nil, err
 	}
-	if !out.Result.Result {
-		if out.Message != "" {
-			return nil, errors.New(out.Message)
-		}
-		return nil, errors.New("constant call failed")
-	}
-	if len(out.ConstantResult) == 0 {
-		return nil, errors.New("empty constant_result")
-	}
-	return parseUint256Hex(out.ConstantResult[0])
+	return new(big.Int).SetBytes(ret), nil
 }
 
 func (t *TRC20) Decimals(ctx context.Context) (uint8, error) {
@@ -90,66 +78,25 @@ func (t *TRC20) Symbol(ctx context.Context) (string, error) {
 	return t.callStringBestEffort(ctx, "symbol()", ownerFromAddressStub)
 }
 
-func (t *TRC20) callUint256NoArgs(ctx context.Context, fn string, ownerFrom string) (*big.Int, error) {
-	raw, err := t.c.TriggerConstantContract(ctx, TriggerConstantContractReq{
-		OwnerAddress:    ownerFrom,
-		ContractAddress: t.contract,
-		Function:        fn,
-		Visible:         t.c.visible,
-	})
+func (t *TRC20) callUint256NoArgs(ctx context.Context, fn string, ownerFrom Address) (*big.Int, error) {
+	ret, err := t.c.constantCall(ctx, ownerFrom, t.contract, fn, "")
 	if err != nil {
 		return nil, err
 	}
-	var out TriggerConstResult
-	if err := json.Unmarshal(raw, &out); err != nil {
-		return nil, err
-	}
-	if !out.Result.Result {
-		if out.Message != "" {
-			return nil, errors.New(out.Message)
-		}
-		return nil, errors.New("constant call failed")
-	}
-	if len(out.ConstantResult) == 0 {
-		return nil, errors.New("empty constant_result")
-	}
-	return parseUint256Hex(out.ConstantResult[0])
+	return new(big.Int).SetBytes(ret), nil
 }
 
-func (t *TRC20) callStringBestEffort(ctx context.Context, fn string, ownerFrom string) (string, error) {
-	raw, err := t.c.TriggerConstantContract(ctx, TriggerConstantContractReq{
-		OwnerAddress:    ownerFrom,
-		ContractAddress: t.contract,
-		Function:        fn,
-		Visible:         t.c.visible,
-	})
-	if err != nil {
-		return "", err
-	}
-
-	var out TriggerConstResult
-	if err := json.Unmarshal(raw, &out); err != nil {
-		return "", err
-	}
-	if !out.Result.Result {
-		if out.Message != "" {
-			return "", errors.New(out.Message)
-		}
-		return "", errors.New("constant call failed")
-	}
-	if len(out.ConstantResult) == 0 {
-		return "", errors.New("empty constant_result")
-	}
-
-	hexRet := strings.TrimPrefix(out.ConstantResult[0], "0x")
-	hexRet = strings.TrimPrefix(hexRet, "0X")
-	hexRet = strings.TrimSpace(hexRet)
-
-	b, err := hex.DecodeString(hexRet)
+func (t *TRC20) callStringBestEffort(ctx context.Context, fn string, ownerFrom Address) (string, error) {
+	b, err := t.c.constantCall(ctx, ownerFrom, t.contract, fn, "")
 	if err != nil {
 		return "", err
 	}
+	return decodeStringReturn(b), nil
+}
 
+// decodeStringReturn decodes an ABI string and falls back to bytes32, which
+// older tokens (MKR-style) return from name() and symbol().
+func decodeStringReturn(b []byte) string {
 	if len(b) >= 64 {
 		off := new(big.Int).SetBytes(b[:32]).Int64()
 		if off >= 0 && off+32 <= int64(len(b)) {
@@ -157,13 +104,13 @@ func (t *TRC20) callStringBestEffort(ctx context.Context, fn string, ownerFrom s
 			start := off + 32
 			end := start + l
 			if l >= 0 && end <= int64(len(b)) {
-				return string(b[start:end]), nil
+				return string(b[start:end])
 			}
 		}
 	}
 
 	trim := bytesTrimRightZero(b)
-	return string(trim), nil
+	return string(trim)
 }
 
 func bytesTrimRightZero(b []byte) []byte {
@@ -174,15 +121,6 @@ func bytesTrimRightZero(b []byte) []byte {
 	return b[:i]
 }
 
-func parseUint256Hex(h string) (*big.Int, error) {
-	h = strings.TrimPrefix(strings.TrimSpace(h), "0x")
-	b, err := hex.DecodeString(h)
-	if err != nil {
-		return nil, err
-	}
-	return new(big.Int).SetBytes(b), nil
-}
-
 type TriggerSmartResult struct {
 	Result struct {
 		Result bool `json:"result"`
@@ -193,54 +131,151 @@ type TriggerSmartResult struct {
 
 func (t *TRC20) BuildTransferTx(
 	ctx context.Context,
-	ownerFrom string,
-	to string,
+	ownerFrom Address,
+	to Address,
+	amount *big.Int,
+	feeLimit int64,
+	opts ...TxOption,
+) (json.RawMessage, error) {
+	return t.buildAddressAmountTx(ctx, ownerFrom, "transfer(address,uint256)", to, amount, feeLimit, opts...)
+}
+
+func (t *TRC20) BuildApproveTx(
+	ctx context.Context,
+	owner Address,
+	spender Address,
+	amount *big.Int,
+	feeLimit int64,
+	opts ...TxOption,
+) (json.RawMessage, error) {
+	return t.buildAddressAmountTx(ctx, owner, "approve(address,uint256)", spender, amount, feeLimit, opts...)
+}
+
+// BuildIncreaseAllowanceTx calls the OpenZeppelin increaseAllowance extension,
+// which avoids the approve front-running race. Not every token implements it.
+func (t *TRC20) BuildIncreaseAllowanceTx(
+	ctx context.Context,
+	owner Address,
+	spender Address,
+	addedValue *big.Int,
+	feeLimit int64,
+	opts ...TxOption,
+) (json.RawMessage, error) {
+	return t.buildAddressAmountTx(ctx, owner, "increaseAllowance(address,uint256)", spender, addedValue, feeLimit, opts...)
+}
+
+func (t *TRC20) BuildDecreaseAllowanceTx(
+	ctx context.Context,
+	owner Address,
+	spender Address,
+	subtractedValue *big.Int,
+	feeLimit int64,
+	opts ...TxOption,
+) (json.RawMessage, error) {
+	return t.buildAddressAmountTx(ctx, owner, "decreaseAllowance(address,uint256)", spender, subtractedValue, feeLimit, opts...)
+}
+
+func (t *TRC20) BuildTransferFromTx(
+	ctx context.Context,
+	spender Address,
+	from Address,
+	to Address,
 	amount *big.Int,
 	feeLimit int64,
+	opts ...TxOption,
 ) (json.RawMessage, error) {
 	if amount == nil || amount.Sign() < 0 {
 		return nil, errors.New("amount must be non-negative")
 	}
 
-	toP, err := ABIEncodeAddressParam(to)
+	amtP, err := ABIEncodeUint256Param(amount)
 	if err != nil {
 		return nil, err
 	}
-	amtP, err := ABIEncodeUint256Param(amount)
+	param, err := ABIConcatParams(ABIEncodeAddress(from), ABIEncodeAddress(to), amtP)
 	if err != nil {
 		return nil, err
 	}
-	param, err := ABIConcatParams(toP, amtP)
+
+	return t.c.buildTriggerTx(ctx, spender, t.contract, "transferFrom(address,address,uint256)", param, feeLimit, opts...)
+}
+
+func (t *TRC20) buildAddressAmountTx(
+	ctx context.Context,
+	ownerFrom Address,
+	fn string,
+	addr Address,
+	amount *big.Int,
+	feeLimit int64,
+	opts ...TxOption,
+) (json.RawMessage, error) {
+	if amount == nil || amount.Sign() < 0 {
+		return nil, errors.New("amount must be non-negative")
+	}
+
+	amtP, err := ABIEncodeUint256Param(amount)
 	if err != nil {
 		return nil, err
 	}
-
-	raw, err := t.c.TriggerSmartContract(ctx, TriggerSmartContractReq{
-		OwnerAddress:    ownerFrom,
-		ContractAddress: t.contract,
-		Function:        "transfer(address,uint256)",
-		Parameter:       param,
-		FeeLimit:        feeLimit,
-		Visible:         t.c.visible,
-	})
+	param, err := ABIConcatParams(ABIEncodeAddress(addr), amtP)
 	if err != nil {
 		return nil, err
 	}
 
-	var out TriggerSmartResult
-	if err := json.Unmarshal(raw, &out); err != nil {
-		return nil, err
+	return t.c.buildTriggerTx(ctx, ownerFrom, t.contract, fn, param, feeLimit, opts...)
+}
+
+// DecodeBoolReturn interprets the return data of transfer, transferFrom and
+// approve. USDT on TRON returns nothing from these, so empty return data counts
+// as success, matching OpenZeppelin's SafeERC20.
+func DecodeBoolReturn(ret []byte) (bool, error) {
+	if len(ret) == 0 {
+		return true, nil
+	}
+	if len(ret) != 32 {
+		return false, fmt.Errorf("unexpected bool return length %d", len(ret))
 	}
-	if !out.Result.Result {
-		if out.Message != "" {
-			return nil, errors.New(out.Message)
+	for _, b := range ret[:31] {
+		if b != 0 {
+			return false, errors.New("invalid bool return value")
 		}
-		return nil, errors.New("triggersmartcontract failed")
 	}
-	if len(out.Transaction) == 0 {
-		return nil, errors.New("empty transaction")
+	switch ret[31] {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	}
+	return false, errors.New("invalid bool return value")
+}
+
+var ErrTransactionPending = errors.New("transaction is pending")
+
+type contractCallResult struct {
+	BlockNumber int64 `json:"blockNumber"`
+	Receipt     struct {
+		Result string `json:"result"`
+	} `json:"receipt"`
+}
+
+// CallSucceeded reports whether a mined transfer, transferFrom or approve
+// transaction succeeded according to its receipt. The bool the token returns
+// is ignored: USDT on TRON returns false from transfer even when it moves the
+// funds. It returns ErrTransactionPending until the tx is mined.
+func (t *TRC20) CallSucceeded(ctx context.Context, txID string) (bool, error) {
+	raw, err := t.c.GetTransactionInfoByID(ctx, txID)
+	if err != nil {
+		return false, err
+	}
+
+	var info contractCallResult
+	if err := json.Unmarshal(raw, &info); err != nil {
+		return false, err
+	}
+	if info.BlockNumber == 0 {
+		return false, ErrTransactionPending
 	}
-	return out.Transaction, nil
+	return info.Receipt.Result == TxStatusSuccess, nil
 }
 
 type TronTx struct {
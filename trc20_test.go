@@ -0,0 +1,130 @@
+package tron_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+const feeLimit = 100_000_000
+
+func TestTRC20Allowance(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	alice, bob, carol := trontest.NewKey("alice"), trontest.NewKey("bob"), trontest.NewKey("carol")
+	node.Fund(alice.Address, 100_000_000)
+	node.Fund(bob.Address, 100_000_000)
+	addr := deploy(t, node, mockTRC20(false), map[common.Hash]common.Hash{
+		slot(alice.Address): common.BigToHash(big.NewInt(1_000)),
+	})
+	c := node.Client()
+	token := c.NewTRC20(addr)
+
+	decimals, err := token.Decimals(ctx)
+	if err != nil || decimals != 6 {
+		t.Fatalf("decimals = %d, %v; want 6", decimals, err)
+	}
+
+	tx, err := token.BuildApproveTx(ctx, alice.Address, bob.Address, big.NewInt(300), feeLimit)
+	if err != nil {
+		t.Fatalf("build approve: %v", err)
+	}
+	if ok, err := token.CallSucceeded(ctx, send(t, c, tx, alice)); err != nil || !ok {
+		t.Fatalf("approve succeeded = %v, %v", ok, err)
+	}
+
+	tx, err = token.BuildIncreaseAllowanceTx(ctx, alice.Address, bob.Address, big.NewInt(50), feeLimit)
+	if err != nil {
+		t.Fatalf("build increaseAllowance: %v", err)
+	}
+	send(t, c, tx, alice)
+	tx, err = token.BuildDecreaseAllowanceTx(ctx, alice.Address, bob.Address, big.NewInt(100), feeLimit)
+	if err != nil {
+		t.Fatalf("build decreaseAllowance: %v", err)
+	}
+	send(t, c, tx, alice)
+
+	allowance, err := token.Allowance(ctx, alice.Address, bob.Address)
+	if err != nil || allowance.Int64() != 250 {
+		t.Fatalf("allowance = %v, %v; want 250", allowance, err)
+	}
+
+	tx, err = token.BuildTransferFromTx(ctx, bob.Address, alice.Address, carol.Address, big.NewInt(200), feeLimit)
+	if err != nil {
+		t.Fatalf("build transferFrom: %v", err)
+	}
+	if ok, err := token.CallSucceeded(ctx, send(t, c, tx, bob)); err != nil || !ok {
+		t.Fatalf("transferFrom succeeded = %v, %v", ok, err)
+	}
+
+	// More than the remaining allowance: the call reverts but is still mined.
+	tx, err = token.BuildTransferFromTx(ctx, bob.Address, alice.Address, carol.Address, big.NewInt(51), feeLimit)
+	if err != nil {
+		t.Fatalf("build transferFrom: %v", err)
+	}
+	if ok, err := token.CallSucceeded(ctx, send(t, c, tx, bob)); err != nil || ok {
+		t.Fatalf("over-allowance transferFrom succeeded = %v, %v; want false", ok, err)
+	}
+
+	for holder, want := range map[tron.Address]int64{alice.Address: 800, carol.Address: 200} {
+		got, err := token.BalanceOf(ctx, holder)
+		if err != nil || got.Int64() != want {
+			t.Fatalf("balance of %s = %v, %v; want %d", holder, got, err, want)
+		}
+	}
+}
+
+// USDT on TRON returns false from transfer; the receipt decides.
+func TestTRC20CallSucceededIgnoresReturnValue(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 100_000_000)
+	addr := deploy(t, node, mockTRC20(true), map[common.Hash]common.Hash{
+		slot(alice.Address): common.BigToHash(big.NewInt(1_000)),
+	})
+	c := node.Client()
+	token := c.NewTRC20(addr)
+
+	tx, err := token.BuildTransferTx(ctx, alice.Address, bob.Address, big.NewInt(10), feeLimit)
+	if err != nil {
+		t.Fatalf("build transfer: %v", err)
+	}
+	ok, err := token.CallSucceeded(ctx, send(t, c, tx, alice))
+	if err != nil || !ok {
+		t.Fatalf("transfer succeeded = %v, %v; want true", ok, err)
+	}
+	if got, _ := token.BalanceOf(ctx, bob.Address); got.Int64() != 10 {
+		t.Fatalf("bob balance = %s, want 10", got)
+	}
+}
+
+func TestTRC20CallSucceededPending(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 100_000_000)
+	addr := deploy(t, node, mockTRC20(false), nil)
+	c := node.Client()
+	token := c.NewTRC20(addr)
+
+	tx, err := token.BuildApproveTx(ctx, alice.Address, bob.Address, big.NewInt(1), feeLimit)
+	if err != nil {
+		t.Fatalf("build approve: %v", err)
+	}
+	txID := send(t, c, tx, alice)
+	if _, err := token.CallSucceeded(ctx, txID); !errors.Is(err, tron.ErrTransactionPending) {
+		t.Fatalf("err = %v, want ErrTransactionPending", err)
+	}
+}
@@ -0,0 +1,180 @@
+package tron
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	InterfaceIDERC165             = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	InterfaceIDTRC721             = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	InterfaceIDTRC721Metadata     = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	InterfaceIDTRC721Enumerable   = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	InterfaceIDTRC1155            = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	InterfaceIDTRC1155MetadataURI = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+)
+
+// The 3-argument safeTransferFrom comes first so go-ethereum names it
+// "safeTransferFrom" and the data variant "safeTransferFrom0".
+const trc721ABIJSON = `[
+{"inputs":[{"name":"owner","type":"address"}],"name":"balanceOf","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"tokenId","type":"uint256"}],"name":"ownerOf","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"tokenId","type":"uint256"}],"name":"tokenURI","outputs":[{"name":"","type":"string"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"owner","type":"address"},{"name":"index","type":"uint256"}],"name":"tokenOfOwnerByIndex","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"tokenId","type":"uint256"}],"name":"getApproved","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"owner","type":"address"},{"name":"operator","type":"address"}],"name":"isApprovedForAll","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"interfaceId","type":"bytes4"}],"name":"supportsInterface","outputs":[{"name":"","type":"bool"}],"stateMutability":"view","type":"function"},
+{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"name":"approve","outputs":[],"stateMutability":"nonpayable","type":"function"},
+{"inputs":[{"name":"operator","type":"address"},{"name":"approved","type":"bool"}],"name":"setApprovalForAll","outputs":[],"stateMutability":"nonpayable","type":"function"}
+]`
+
+var trc721ABI = mustParseABI(trc721ABIJSON)
+
+type TRC721 struct {
+	c        *Client
+	contract Address
+}
+
+func (c *Client) NewTRC721(contract Address) *TRC721 {
+	return &TRC721{c: c, contract: contract}
+}
+
+func (t *TRC721) BalanceOf(ctx context.Context, owner Address) (*big.Int, error) {
+	out, err := t.c.callABI(ctx, owner, t.contract, &trc721ABI, "balanceOf", owner.EVM())
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+func (t *TRC721) OwnerOf(ctx context.Context, tokenID *big.Int) (Address, error) {
+	out, err := t.c.callABI(ctx, ownerFromAddressStub, t.contract, &trc721ABI, "ownerOf", tokenID)
+	if err != nil {
+		return Address{}, err
+	}
+	return AddressFromEVM(out[0].(common.Address)), nil
+}
+
+func (t *TRC721) TokenURI(ctx context.Context, tokenID *big.Int) (string, error) {
+	out, err := t.c.callABI(ctx, ownerFromAddressStub, t.contract, &trc721ABI, "tokenURI", tokenID)
+	if err != nil {
+		return "", err
+	}
+	return out[0].(string), nil
+}
+
+// TokenOfOwnerByIndex requires the enumerable extension.
+func (t *TRC721) TokenOfOwnerByIndex(ctx context.Context, owner Address, index *big.Int) (*big.Int, error) {
+	out, err := t.c.callABI(ctx, owner, t.contract, &trc721ABI, "tokenOfOwnerByIndex", owner.EVM(), index)
+	if err != nil {
+		return nil, err
+	}
+	return out[0].(*big.Int), nil
+}
+
+// TokensOfOwner lists every token of owner via the enumerable extension.
+func (t *TRC721) TokensOfOwner(ctx context.Context, owner Address) ([]*big.Int, error) {
+	n, err := t.BalanceOf(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if !n.IsInt64() {
+		return nil, fmt.Errorf("balance %s too large to enumerate", n)
+	}
+
+	ids := make([]*big.Int, 0, n.Int64())
+	for i := int64(0); i < n.Int64(); i++ {
+		id, err := t.TokenOfOwnerByIndex(ctx, owner, big.NewInt(i))
+		if err != nil {
+			return nil, fmt.Errorf("token of owner[%d]: %w", i, err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (t *TRC721) GetApproved(ctx context.Context, tokenID *big.Int) (Address, error) {
+	out, err := t.c.callABI(ctx, ownerFromAddressStub, t.contract, &trc721ABI, "getApproved", tokenID)
+	if err != nil {
+		return Address{}, err
+	}
+	return AddressFromEVM(out[0].(common.Address)), nil
+}
+
+func (t *TRC721) IsApprovedForAll(ctx context.Context, owner Address, operator Address) (bool, error) {
+	out, err := t.c.callABI(ctx, owner, t.contract, &trc721ABI, "isApprovedForAll", owner.EVM(), operator.EVM())
+	if err != nil {
+		return false, err
+	}
+	return out[0].(bool), nil
+}
+
+func (t *TRC721) SupportsInterface(ctx context.Context, interfaceID [4]byte) (bool, error) {
+	return supportsInterface(ctx, t.c, t.contract, interfaceID)
+}
+
+// supportsInterface follows ERC-165 detection: a contract that reverts or
+// returns anything but a single word does not support the interface.
+func supportsInterface(ctx context.Context, c *Client, contract Address, interfaceID [4]byte) (bool, error) {
+	m := trc721ABI.Methods["supportsInterface"]
+	input, err := trc721ABI.Pack("supportsInterface", interfaceID)
+	if err != nil {
+		return false, err
+	}
+
+	ret, err := c.constantCall(ctx, ownerFromAddressStub, contract, m.Sig, hex.EncodeToString(input[4:]))
+	var callErr *ContractCallError
+	if errors.As(err, &callErr) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(ret) != 32 {
+		return false, nil
+	}
+	return new(big.Int).SetBytes(ret).Sign() != 0, nil
+}
+
+// BuildSafeTransferFromTx uses the data overload only when data is non-empty.
+func (t *TRC721) BuildSafeTransferFromTx(
+	ctx context.Context,
+	from Address,
+	to Address,
+	tokenID *big.Int,
+	data []byte,
+	feeLimit int64,
+) (json.RawMessage, error) {
+	if len(data) == 0 {
+		return t.c.buildABITx(ctx, from, t.contract, &trc721ABI, "safeTransferFrom", feeLimit, from.EVM(), to.EVM(), tokenID)
+	}
+	return t.c.buildABITx(ctx, from, t.contract, &trc721ABI, "safeTransferFrom0", feeLimit, from.EVM(), to.EVM(), tokenID, data)
+}
+
+func (t *TRC721) BuildApproveTx(
+	ctx context.Context,
+	owner Address,
+	to Address,
+	tokenID *big.Int,
+	feeLimit int64,
+) (json.RawMessage, error) {
+	return t.c.buildABITx(ctx, owner, t.contract, &trc721ABI, "approve", feeLimit, to.EVM(), tokenID)
+}
+
+func (t *TRC721) BuildSetApprovalForAllTx(
+	ctx context.Context,
+	owner Address,
+	operator Address,
+	approved bool,
+	feeLimit int64,
+) (json.RawMessage, error) {
+	return t.c.buildABITx(ctx, owner, t.contract, &trc721ABI, "setApprovalForAll", feeLimit, operator.EVM(), approved)
+}
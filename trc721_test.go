@@ -0,0 +1,102 @@
+package tron_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestTRC721(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode(trontest.WithAutoBlock(true))
+	defer node.Close()
+
+	alice, bob, carol := trontest.NewKey("alice"), trontest.NewKey("bob"), trontest.NewKey("carol")
+	node.Fund(alice.Address, 100_000_000)
+	node.Fund(bob.Address, 100_000_000)
+	addr := deploy(t, node, mockTRC721(), nftStorage(map[int64]tron.Address{
+		1: alice.Address,
+		2: alice.Address,
+		3: bob.Address,
+	}))
+	c := node.Client()
+	nft := c.NewTRC721(addr)
+
+	ids, err := nft.TokensOfOwner(ctx, alice.Address)
+	if err != nil || len(ids) != 2 || ids[0].Int64() != 1 || ids[1].Int64() != 2 {
+		t.Fatalf("tokens of alice = %v, %v; want [1 2]", ids, err)
+	}
+	if uri, err := nft.TokenURI(ctx, big.NewInt(1)); err != nil || uri != "ipfs://token" {
+		t.Fatalf("tokenURI = %q, %v", uri, err)
+	}
+	if _, err := nft.OwnerOf(ctx, big.NewInt(99)); err == nil {
+		t.Fatal("ownerOf a missing token succeeded")
+	}
+
+	tx, err := nft.BuildApproveTx(ctx, alice.Address, bob.Address, big.NewInt(1), feeLimit)
+	if err != nil {
+		t.Fatalf("build approve: %v", err)
+	}
+	send(t, c, tx, alice)
+	if approved, err := nft.GetApproved(ctx, big.NewInt(1)); err != nil || approved != bob.Address {
+		t.Fatalf("approved = %s, %v; want %s", approved, err, bob.Address)
+	}
+
+	for id, data := range map[int64][]byte{1: nil, 2: []byte("memo")} {
+		tx, err = nft.BuildSafeTransferFromTx(ctx, alice.Address, carol.Address, big.NewInt(id), data, feeLimit)
+		if err != nil {
+			t.Fatalf("build safeTransferFrom(%d): %v", id, err)
+		}
+		send(t, c, tx, alice)
+		if owner, err := nft.OwnerOf(ctx, big.NewInt(id)); err != nil || owner != carol.Address {
+			t.Fatalf("owner of %d = %s, %v; want %s", id, owner, err, carol.Address)
+		}
+	}
+	if n, err := nft.BalanceOf(ctx, carol.Address); err != nil || n.Int64() != 2 {
+		t.Fatalf("carol balance = %v, %v; want 2", n, err)
+	}
+	if approved, _ := nft.GetApproved(ctx, big.NewInt(1)); approved != tron.AddressFromEVM(common.Address{}) {
+		t.Fatalf("approval of 1 survived the transfer: %s", approved)
+	}
+
+	tx, err = nft.BuildSetApprovalForAllTx(ctx, bob.Address, carol.Address, true, feeLimit)
+	if err != nil {
+		t.Fatalf("build setApprovalForAll: %v", err)
+	}
+	send(t, c, tx, bob)
+	if ok, err := nft.IsApprovedForAll(ctx, bob.Address, carol.Address); err != nil || !ok {
+		t.Fatalf("isApprovedForAll = %v, %v; want true", ok, err)
+	}
+}
+
+func TestTRC721SupportsInterface(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+
+	c := node.Client()
+	nft := c.NewTRC721(deploy(t, node, mockTRC721(), nil))
+	for id, want := range map[[4]byte]bool{
+		tron.InterfaceIDERC165:           true,
+		tron.InterfaceIDTRC721:           true,
+		tron.InterfaceIDTRC721Enumerable: false,
+	} {
+		if got, err := nft.SupportsInterface(ctx, id); err != nil || got != want {
+			t.Fatalf("supportsInterface(%x) = %v, %v; want %v", id, got, err, want)
+		}
+	}
+
+	// A contract that reverts or returns nothing supports no interface.
+	reverts := deploy(t, node, newAsm().push(0).op(vm.DUP1, vm.REVERT).bytes(), nil)
+	empty := deploy(t, node, newAsm().op(vm.STOP).bytes(), nil)
+	for _, addr := range []tron.Address{reverts, empty} {
+		if got, err := c.NewTRC721(addr).SupportsInterface(ctx, tron.InterfaceIDERC165); err != nil || got {
+			t.Fatalf("supportsInterface on %s = %v, %v; want false, nil", addr, got, err)
+		}
+	}
+}
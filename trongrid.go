@@ -0,0 +1,315 @@
+package tron
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const maxTronGridPageSize = 200
+
+// TronGrid queries the indexed TronGrid v1 API (GET /v1/...) through the
+// client, so it shares its headers, API key, retries and interceptors. The
+// client must point at a TronGrid host such as https://api.trongrid.io.
+type TronGrid struct {
+	c *Client
+}
+
+func (c *Client) NewTronGrid() *TronGrid {
+	return &TronGrid{c: c}
+}
+
+// Order is the block_timestamp sort order of list queries.
+type Order string
+
+const (
+	OrderDesc Order = "desc"
+	OrderAsc  Order = "asc"
+)
+
+type TransactionsQuery struct {
+	OnlyConfirmed   bool
+	OnlyUnconfirmed bool
+	OnlyTo          bool
+	OnlyFrom        bool
+	// MinTimestamp and MaxTimestamp bound block_timestamp, in milliseconds.
+	MinTimestamp int64
+	MaxTimestamp int64
+	Order        Order
+	// PageSize defaults to 200, the maximum TronGrid allows.
+	PageSize int
+	// SkipInternal excludes internal transactions from the results.
+	SkipInternal bool
+}
+
+func (q TransactionsQuery) values() url.Values {
+	v := listValues(q.OnlyConfirmed, q.OnlyUnconfirmed, q.MinTimestamp, q.MaxTimestamp, q.Order, q.PageSize)
+	setBool(v, "only_to", q.OnlyTo)
+	setBool(v, "only_from", q.OnlyFrom)
+	if q.SkipInternal {
+		v.Set("search_internal", "false")
+	}
+	return v
+}
+
+type TRC20TransfersQuery struct {
+	OnlyConfirmed   bool
+	OnlyUnconfirmed bool
+	OnlyTo          bool
+	OnlyFrom        bool
+	// Contract limits the results to one token.
+	Contract     Address
+	MinTimestamp int64
+	MaxTimestamp int64
+	Order        Order
+	PageSize     int
+}
+
+func (q TRC20TransfersQuery) values() url.Values {
+	v := listValues(q.OnlyConfirmed, q.OnlyUnconfirmed, q.MinTimestamp, q.MaxTimestamp, q.Order, q.PageSize)
+	setBool(v, "only_to", q.OnlyTo)
+	setBool(v, "only_from", q.OnlyFrom)
+	if !q.Contract.IsZero() {
+		v.Set("contract_address", q.Contract.Base58())
+	}
+	return v
+}
+
+type EventsQuery struct {
+	OnlyConfirmed   bool
+	OnlyUnconfirmed bool
+	// EventName filters by event name, e.g. "Transfer".
+	EventName   string
+	BlockNumber int64
+	// MinTimestamp and MaxTimestamp bound block_timestamp, in milliseconds.
+	MinTimestamp int64
+	MaxTimestamp int64
+	Order        Order
+	PageSize     int
+}
+
+func (q EventsQuery) values() url.Values {
+	v := url.Values{}
+	setBool(v, "only_confirmed", q.OnlyConfirmed)
+	setBool(v, "only_unconfirmed", q.OnlyUnconfirmed)
+	if q.EventName != "" {
+		v.Set("event_name", q.EventName)
+	}
+	if q.BlockNumber > 0 {
+		v.Set("block_number", strconv.FormatInt(q.BlockNumber, 10))
+	}
+	if q.MinTimestamp > 0 {
+		v.Set("min_block_timestamp", strconv.FormatInt(q.MinTimestamp, 10))
+	}
+	if q.MaxTimestamp > 0 {
+		v.Set("max_block_timestamp", strconv.FormatInt(q.MaxTimestamp, 10))
+	}
+	if q.Order != "" {
+		v.Set("order_by", "block_timestamp,"+string(q.Order))
+	}
+	v.Set("limit", strconv.Itoa(pageSize(q.PageSize)))
+	return v
+}
+
+func listValues(confirmed, unconfirmed bool, minTS, maxTS int64, order Order, size int) url.Values {
+	v := url.Values{}
+	setBool(v, "only_confirmed", confirmed)
+	setBool(v, "only_unconfirmed", unconfirmed)
+	if minTS > 0 {
+		v.Set("min_timestamp", strconv.FormatInt(minTS, 10))
+	}
+	if maxTS > 0 {
+		v.Set("max_timestamp", strconv.FormatInt(maxTS, 10))
+	}
+	if order != "" {
+		v.Set("order_by", "block_timestamp,"+string(order))
+	}
+	v.Set("limit", strconv.Itoa(pageSize(size)))
+	return v
+}
+
+func setBool(v url.Values, key string, b bool) {
+	if b {
+		v.Set(key, "true")
+	}
+}
+
+func pageSize(n int) int {
+	if n <= 0 || n > maxTronGridPageSize {
+		return maxTronGridPageSize
+	}
+	return n
+}
+
+// GridTransaction is a transaction as listed by TronGrid. Internal
+// transactions only fill InternalTxID, ParentTxID, From, To, BlockTimestamp and
+// Data.
+type GridTransaction struct {
+	TxID             string          `json:"txID"`
+	BlockNumber      int64           `json:"blockNumber"`
+	BlockTimestamp   int64           `json:"block_timestamp"`
+	RawData          json.RawMessage `json:"raw_data,omitempty"`
+	RawDataHex       string          `json:"raw_data_hex,omitempty"`
+	Signature        []string        `json:"signature,omitempty"`
+	Ret              []GridTxResult  `json:"ret,omitempty"`
+	NetUsage         int64           `json:"net_usage"`
+	NetFee           int64           `json:"net_fee"`
+	EnergyUsage      int64           `json:"energy_usage"`
+	EnergyFee        int64           `json:"energy_fee"`
+	EnergyUsageTotal int64           `json:"energy_usage_total"`
+
+	InternalTxID string          `json:"internal_tx_id,omitempty"`
+	ParentTxID   string          `json:"tx_id,omitempty"`
+	From         string          `json:"from_address,omitempty"`
+	To           string          `json:"to_address,omitempty"`
+	Data         json.RawMessage `json:"data,omitempty"`
+}
+
+type GridTxResult struct {
+	ContractRet string `json:"contractRet"`
+	Fee         int64  `json:"fee"`
+}
+
+func (t GridTransaction) Internal() bool {
+	return t.InternalTxID != ""
+}
+
+type GridTokenInfo struct {
+	Address  Address `json:"address"`
+	Symbol   string  `json:"symbol"`
+	Name     string  `json:"name"`
+	Decimals uint8   `json:"decimals"`
+}
+
+type TRC20Transfer struct {
+	TransactionID  string        `json:"transaction_id"`
+	TokenInfo      GridTokenInfo `json:"token_info"`
+	BlockTimestamp int64         `json:"block_timestamp"`
+	From           Address       `json:"from"`
+	To             Address       `json:"to"`
+	Type           string        `json:"type"`
+	Value          string        `json:"value"`
+}
+
+// Amount returns the transferred value in token units.
+func (t TRC20Transfer) Amount() (Amount, error) {
+	v, ok := new(big.Int).SetString(t.Value, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid transfer value %q", t.Value)
+	}
+	return NewAmount(v, t.TokenInfo.Decimals), nil
+}
+
+// GridEvent is a decoded contract event. Result holds the event arguments by
+// position and by name; addresses in it are 0x-prefixed EVM hex.
+type GridEvent struct {
+	TransactionID         string            `json:"transaction_id"`
+	BlockNumber           int64             `json:"block_number"`
+	BlockTimestamp        int64             `json:"block_timestamp"`
+	ContractAddress       Address           `json:"contract_address"`
+	CallerContractAddress Address           `json:"caller_contract_address"`
+	EventIndex            int               `json:"event_index"`
+	EventName             string            `json:"event_name"`
+	Event                 string            `json:"event"`
+	Result                map[string]any    `json:"result"`
+	ResultType            map[string]string `json:"result_type"`
+	Unconfirmed           bool              `json:"_unconfirmed,omitempty"`
+}
+
+type gridMeta struct {
+	At          int64  `json:"at"`
+	Fingerprint string `json:"fingerprint"`
+	PageSize    int    `json:"page_size"`
+}
+
+type gridResp[T any] struct {
+	Data    []T      `json:"data"`
+	Success bool     `json:"success"`
+	Error   string   `json:"error"`
+	Meta    gridMeta `json:"meta"`
+}
+
+func gridGet[T any](ctx context.Context, g *TronGrid, method string, path string, query url.Values) (*gridResp[T], error) {
+	var out gridResp[T]
+	if err := g.c.callWithRetry(ctx, http.MethodGet, method, path, query, &out); err != nil {
+		return nil, err
+	}
+	if !out.Success {
+		if out.Error == "" {
+			return nil, errors.New("trongrid request failed")
+		}
+		return nil, fmt.Errorf("trongrid: %s", out.Error)
+	}
+	return &out, nil
+}
+
+// paginate follows meta.fingerprint until the last page or until the caller
+// stops iterating. method names the endpoint for interceptors without the
+// address, to keep metric labels bounded.
+func paginate[T any](ctx context.Context, g *TronGrid, method string, path string, query url.Values) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		query := maps.Clone(query)
+		for {
+			resp, err := gridGet[T](ctx, g, method, path, query)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range resp.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if resp.Meta.Fingerprint == "" || len(resp.Data) == 0 {
+				return
+			}
+			query.Set("fingerprint", resp.Meta.Fingerprint)
+		}
+	}
+}
+
+// AccountTransactions iterates over the transactions of address, fetching
+// pages as needed:
+//
+//	for tx, err := range grid.AccountTransactions(ctx, addr, tron.TransactionsQuery{}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (g *TronGrid) AccountTransactions(ctx context.Context, address Address, q TransactionsQuery) iter.Seq2[GridTransaction, error] {
+	path := "v1/accounts/" + address.Base58() + "/transactions"
+	return paginate[GridTransaction](ctx, g, "v1/accounts/transactions", path, q.values())
+}
+
+// AccountTRC20Transfers iterates over the TRC20 transfers to and from address.
+func (g *TronGrid) AccountTRC20Transfers(ctx context.Context, address Address, q TRC20TransfersQuery) iter.Seq2[TRC20Transfer, error] {
+	path := "v1/accounts/" + address.Base58() + "/transactions/trc20"
+	return paginate[TRC20Transfer](ctx, g, "v1/accounts/transactions/trc20", path, q.values())
+}
+
+// ContractEvents iterates over the events emitted by contract.
+func (g *TronGrid) ContractEvents(ctx context.Context, contract Address, q EventsQuery) iter.Seq2[GridEvent, error] {
+	path := "v1/contracts/" + contract.Base58() + "/events"
+	return paginate[GridEvent](ctx, g, "v1/contracts/events", path, q.values())
+}
+
+// TransactionEvents returns the events emitted by a transaction.
+func (g *TronGrid) TransactionEvents(ctx context.Context, txID string, onlyConfirmed bool) ([]GridEvent, error) {
+	query := url.Values{}
+	setBool(query, "only_confirmed", onlyConfirmed)
+
+	resp, err := gridGet[GridEvent](ctx, g, "v1/transactions/events", "v1/transactions/"+url.PathEscape(txID)+"/events", query)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
@@ -0,0 +1,146 @@
+package tron_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// gridServer serves pages of transactions chained by fingerprint: page i is
+// requested with fingerprint "fp<i>" and points at "fp<i+1>", the last one at
+// nothing. It records the query of every request.
+func gridServer(t *testing.T, pages ...[]string) (*httptest.Server, *[]url.Values) {
+	t.Helper()
+	var queries []url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q)
+
+		i := 0
+		if fp := q.Get("fingerprint"); fp != "" {
+			if _, err := fmt.Sscanf(fp, "fp%d", &i); err != nil || i >= len(pages) {
+				http.Error(w, "bad fingerprint", http.StatusBadRequest)
+				return
+			}
+		}
+		data := "["
+		for j, id := range pages[i] {
+			if j > 0 {
+				data += ","
+			}
+			data += fmt.Sprintf(`{"txID":%q}`, id)
+		}
+		data += "]"
+		next := ""
+		if i+1 < len(pages) {
+			next = fmt.Sprintf("fp%d", i+1)
+		}
+		fmt.Fprintf(w, `{"success":true,"data":%s,"meta":{"fingerprint":%q}}`, data, next)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &queries
+}
+
+func TestTronGridPagination(t *testing.T) {
+	ctx := context.Background()
+	alice := trontest.NewKey("alice").Address
+	srv, queries := gridServer(t, []string{"a", "b"}, []string{"c"}, []string{"d", "e"})
+	grid := tron.New(srv.URL).NewTronGrid()
+
+	var got []string
+	for tx, err := range grid.AccountTransactions(ctx, alice, tron.TransactionsQuery{
+		OnlyConfirmed: true,
+		OnlyTo:        true,
+		MinTimestamp:  1000,
+		Order:         tron.OrderAsc,
+		PageSize:      2,
+		SkipInternal:  true,
+	}) {
+		if err != nil {
+			t.Fatalf("transactions: %v", err)
+		}
+		got = append(got, tx.TxID)
+	}
+	if fmt.Sprint(got) != "[a b c d e]" {
+		t.Fatalf("transactions = %v, want [a b c d e]", got)
+	}
+
+	if len(*queries) != 3 {
+		t.Fatalf("sent %d requests, want 3", len(*queries))
+	}
+	for i, q := range *queries {
+		want := url.Values{
+			"only_confirmed":  {"true"},
+			"only_to":         {"true"},
+			"min_timestamp":   {"1000"},
+			"order_by":        {"block_timestamp,asc"},
+			"limit":           {"2"},
+			"search_internal": {"false"},
+		}
+		if i > 0 {
+			want.Set("fingerprint", fmt.Sprintf("fp%d", i))
+		}
+		if q.Encode() != want.Encode() {
+			t.Fatalf("query %d = %s, want %s", i, q.Encode(), want.Encode())
+		}
+	}
+}
+
+func TestTronGridPaginationStops(t *testing.T) {
+	ctx := context.Background()
+	alice := trontest.NewKey("alice").Address
+
+	t.Run("caller breaks", func(t *testing.T) {
+		srv, queries := gridServer(t, []string{"a", "b"}, []string{"c"})
+		grid := tron.New(srv.URL).NewTronGrid()
+		for _, err := range grid.AccountTransactions(ctx, alice, tron.TransactionsQuery{}) {
+			if err != nil {
+				t.Fatalf("transactions: %v", err)
+			}
+			break
+		}
+		if len(*queries) != 1 {
+			t.Fatalf("sent %d requests after break, want 1", len(*queries))
+		}
+	})
+
+	t.Run("empty page", func(t *testing.T) {
+		// The second page has no data but still carries a fingerprint.
+		srv, queries := gridServer(t, []string{"a"}, nil, []string{"b"})
+		grid := tron.New(srv.URL).NewTronGrid()
+		n := 0
+		for _, err := range grid.AccountTransactions(ctx, alice, tron.TransactionsQuery{}) {
+			if err != nil {
+				t.Fatalf("transactions: %v", err)
+			}
+			n++
+		}
+		if n != 1 || len(*queries) != 2 {
+			t.Fatalf("got %d transactions in %d requests, want 1 in 2", n, len(*queries))
+		}
+	})
+}
+
+func TestTronGridError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success":false,"error":"invalid address"}`))
+	}))
+	defer srv.Close()
+	grid := tron.New(srv.URL).NewTronGrid()
+
+	n := 0
+	var last error
+	for _, err := range grid.ContractEvents(context.Background(), trontest.NewKey("token").Address, tron.EventsQuery{}) {
+		n++
+		last = err
+	}
+	if n != 1 || last == nil || last.Error() != "trongrid: invalid address" {
+		t.Fatalf("got %d results, last error %v; want a single trongrid error", n, last)
+	}
+}
@@ -0,0 +1,96 @@
+// Package tronotel traces tron.Client node calls with OpenTelemetry.
+package tronotel
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+const instrumentationName = "github.com/snakoner/go-tron-lib/tronotel"
+
+type Option func(*options)
+
+type options struct {
+	provider trace.TracerProvider
+}
+
+// WithTracerProvider overrides the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(o *options) { o.provider = provider }
+}
+
+// Interceptor starts a client span for every call attempt:
+//
+//	c := tron.New(url, tron.WithInterceptor(tronotel.Interceptor()))
+func Interceptor(opts ...Option) tron.Interceptor {
+	o := options{provider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tracer := o.provider.Tracer(instrumentationName)
+
+	return func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			info, _ := tron.CallInfoFromContext(ctx)
+
+			ctx, span := tracer.Start(ctx, "tron "+info.Method,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("tron.method", info.Method),
+					attribute.String("tron.path", path),
+					attribute.String("tron.endpoint", info.Endpoint),
+					attribute.Bool("tron.solid", info.Solid),
+					attribute.Int("tron.attempt", info.Attempt),
+				),
+			)
+			defer span.End()
+
+			err := next(ctx, path, req, out)
+
+			if status := tron.StatusCode(err); status != 0 {
+				span.SetAttributes(attribute.Int("http.response.status_code", status))
+			}
+			if txID := txID(req, out); txID != "" {
+				span.SetAttributes(attribute.String("tron.txid", txID))
+			}
+			if r, ok := out.(*tron.BroadcastResp); ok && err == nil && !r.Result {
+				span.SetAttributes(attribute.String("tron.code", r.Code))
+				span.SetStatus(codes.Error, r.Code)
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		}
+	}
+}
+
+// txID finds the transaction id of transaction lookups and broadcasts.
+func txID(req any, out any) string {
+	if r, ok := out.(*tron.BroadcastResp); ok && r.TxID != "" {
+		return r.TxID
+	}
+
+	switch r := req.(type) {
+	case tron.GetTransactionByIDReq:
+		return r.Value
+	case tron.GetTransactionInfoByIDReq:
+		return r.Value
+	case json.RawMessage:
+		var tx struct {
+			TxID string `json:"txID"`
+		}
+		if json.Unmarshal(r, &tx) == nil {
+			return tx.TxID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package tronotel_test
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/tronotel"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+// recorder is a TracerProvider that keeps the name, attributes and status of
+// every span it starts.
+type recorder struct {
+	noop.TracerProvider
+	mu    sync.Mutex
+	spans []*span
+}
+
+func (r *recorder) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return tracer{r: r}
+}
+
+type tracer struct {
+	noop.Tracer
+	r *recorder
+}
+
+func (t tracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	cfg := trace.NewSpanStartConfig(opts...)
+	s := &span{name: name, kind: cfg.SpanKind(), attrs: make(map[attribute.Key]attribute.Value)}
+	s.SetAttributes(cfg.Attributes()...)
+
+	t.r.mu.Lock()
+	t.r.spans = append(t.r.spans, s)
+	t.r.mu.Unlock()
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+type span struct {
+	noop.Span
+	name   string
+	kind   trace.SpanKind
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	ended  bool
+}
+
+func (s *span) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *span) SetStatus(code codes.Code, _ string) { s.status = code }
+func (s *span) End(...trace.SpanEndOption)          { s.ended = true }
+
+func TestInterceptor(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 1_000_000)
+
+	rec := &recorder{}
+	c := node.SolidClient(tron.WithInterceptor(tronotel.Interceptor(tronotel.WithTracerProvider(rec))))
+
+	if _, err := c.GetAccount(ctx, alice.Address); err != nil {
+		t.Fatalf("get account: %v", err)
+	}
+	tx, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, bob.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.BroadcastTransaction(ctx, signed)
+	if err != nil || resp.Code != "SIGERROR" {
+		t.Fatalf("broadcast = %+v, %v; want SIGERROR", resp, err)
+	}
+
+	if len(rec.spans) != 3 {
+		t.Fatalf("recorded %d spans, want 3", len(rec.spans))
+	}
+	get, broadcast := rec.spans[0], rec.spans[2]
+	for _, s := range rec.spans {
+		if !s.ended || s.kind != trace.SpanKindClient {
+			t.Fatalf("span %q: ended %v, kind %v; want an ended client span", s.name, s.ended, s.kind)
+		}
+	}
+
+	for key, want := range map[attribute.Key]attribute.Value{
+		"tron.method":   attribute.StringValue("getaccount"),
+		"tron.path":     attribute.StringValue("walletsolidity/getaccount"),
+		"tron.endpoint": attribute.StringValue(node.URL()),
+		"tron.solid":    attribute.BoolValue(true),
+		"tron.attempt":  attribute.IntValue(0),
+	} {
+		if got := get.attrs[key]; got != want {
+			t.Fatalf("getaccount span %s = %v, want %v", key, got.Emit(), want.Emit())
+		}
+	}
+	if get.name != "tron getaccount" || get.status != codes.Unset {
+		t.Fatalf("getaccount span %q, status %v", get.name, get.status)
+	}
+
+	if broadcast.attrs["tron.txid"].AsString() != resp.TxID || broadcast.attrs["tron.code"].AsString() != "SIGERROR" {
+		t.Fatalf("broadcast span attributes = %v, want txid %s and code SIGERROR", broadcast.attrs, resp.TxID)
+	}
+	if broadcast.status != codes.Error {
+		t.Fatalf("broadcast span status = %v, want Error", broadcast.status)
+	}
+}
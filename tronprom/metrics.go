@@ -0,0 +1,170 @@
+// Package tronprom exposes Prometheus metrics for tron.Client node calls.
+package tronprom
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+type Option func(*options)
+
+type options struct {
+	namespace   string
+	constLabels prometheus.Labels
+	buckets     []float64
+}
+
+func WithNamespace(namespace string) Option {
+	return func(o *options) { o.namespace = namespace }
+}
+
+// WithConstLabels attaches fixed labels, e.g. the network name, to every
+// metric.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(o *options) { o.constLabels = labels }
+}
+
+func WithBuckets(buckets []float64) Option {
+	return func(o *options) { o.buckets = buckets }
+}
+
+// Metrics is a prometheus.Collector fed by the interceptor it hands out:
+//
+//	m := tronprom.New()
+//	prometheus.MustRegister(m)
+//	c := tron.New(url, tron.WithInterceptor(m.Interceptor()))
+type Metrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	inFlight *prometheus.GaugeVec
+}
+
+func New(opts ...Option) *Metrics {
+	o := options{
+		namespace: "tron",
+		buckets:   prometheus.DefBuckets,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "requests_total",
+			Help:        "Node call attempts by method, API and result code.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "solid", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "errors_total",
+			Help:        "Failed node call attempts by method and error code.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "solid", "code"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "retries_total",
+			Help:        "Node call attempts after the first by method.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "solid"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "request_duration_seconds",
+			Help:        "Latency of node call attempts.",
+			ConstLabels: o.constLabels,
+			Buckets:     o.buckets,
+		}, []string{"method", "solid"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   o.namespace,
+			Subsystem:   "client",
+			Name:        "in_flight_requests",
+			Help:        "Node call attempts currently in flight.",
+			ConstLabels: o.constLabels,
+		}, []string{"method", "solid"}),
+	}
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.errors.Describe(ch)
+	m.retries.Describe(ch)
+	m.latency.Describe(ch)
+	m.inFlight.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.errors.Collect(ch)
+	m.retries.Collect(ch)
+	m.latency.Collect(ch)
+	m.inFlight.Collect(ch)
+}
+
+func (m *Metrics) Interceptor() tron.Interceptor {
+	return func(next tron.Invoker) tron.Invoker {
+		return func(ctx context.Context, path string, req any, out any) error {
+			info, _ := tron.CallInfoFromContext(ctx)
+			method, solid := info.Method, strconv.FormatBool(info.Solid)
+			if method == "" {
+				method = "unknown"
+			}
+
+			if info.Attempt > 0 {
+				m.retries.WithLabelValues(method, solid).Inc()
+			}
+			inFlight := m.inFlight.WithLabelValues(method, solid)
+			inFlight.Inc()
+			start := time.Now()
+
+			err := next(ctx, path, req, out)
+
+			inFlight.Dec()
+			m.latency.WithLabelValues(method, solid).Observe(time.Since(start).Seconds())
+
+			c := code(err, out)
+			m.requests.WithLabelValues(method, solid, c).Inc()
+			if c != "OK" {
+				m.errors.WithLabelValues(method, solid, c).Inc()
+			}
+			return err
+		}
+	}
+}
+
+// code classifies the outcome of a call: "OK", the node's broadcast code
+// (e.g. "SIGERROR"), the HTTP status, or the kind of transport failure.
+func code(err error, out any) string {
+	if err == nil {
+		if r, ok := out.(*tron.BroadcastResp); ok && !r.Result && r.Code != "" {
+			return r.Code
+		}
+		return "OK"
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "deadline_exceeded"
+	}
+	if status := tron.StatusCode(err); status != 0 {
+		return strconv.Itoa(status)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return "network"
+	}
+	return "error"
+}
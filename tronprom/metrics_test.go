@@ -0,0 +1,120 @@
+package tronprom_test
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/tronprom"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+type transportFunc func(*http.Request) (*http.Response, error)
+
+func (f transportFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// value returns the counter or histogram count of the series of name whose
+// labels include want.
+func value(t *testing.T, families []*dto.MetricFamily, name string, want map[string]string) float64 {
+	t.Helper()
+
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, m := range f.GetMetric() {
+			labels := make(map[string]string)
+			for _, l := range m.GetLabel() {
+				labels[l.GetName()] = l.GetValue()
+			}
+			for k, v := range want {
+				if labels[k] != v {
+					continue metrics
+				}
+			}
+			switch {
+			case m.Counter != nil:
+				return m.GetCounter().GetValue()
+			case m.Histogram != nil:
+				return float64(m.GetHistogram().GetSampleCount())
+			case m.Gauge != nil:
+				return m.GetGauge().GetValue()
+			}
+		}
+	}
+	t.Fatalf("no %s series with labels %v", name, want)
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	ctx := context.Background()
+	node := trontest.NewNode()
+	defer node.Close()
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 1_000_000)
+
+	// The first getaccount request fails with 503 and is retried.
+	var failed atomic.Bool
+	transport := transportFunc(func(r *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(r.URL.Path, "/getaccount") && failed.CompareAndSwap(false, true) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody, Request: r}, nil
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	m := tronprom.New(tronprom.WithConstLabels(prometheus.Labels{"network": "test"}))
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(m)
+	c := tron.New(node.URL(),
+		tron.WithHTTPClient(&http.Client{Transport: transport}),
+		tron.WithInterceptor(m.Interceptor()),
+		tron.WithRetry(1, 0),
+	)
+
+	if _, err := c.GetAccount(ctx, alice.Address); err != nil {
+		t.Fatalf("get account: %v", err)
+	}
+	tx, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, bob.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := c.BroadcastTransaction(ctx, signed); err != nil || resp.Code != "SIGERROR" {
+		t.Fatalf("broadcast = %+v, %v; want SIGERROR", resp, err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("gather: %v", err)
+	}
+	for _, tc := range []struct {
+		name   string
+		labels map[string]string
+		want   float64
+	}{
+		{"tron_client_requests_total", map[string]string{"method": "getaccount", "solid": "false", "code": "503", "network": "test"}, 1},
+		{"tron_client_requests_total", map[string]string{"method": "getaccount", "code": "OK"}, 1},
+		{"tron_client_errors_total", map[string]string{"method": "getaccount", "code": "503"}, 1},
+		{"tron_client_retries_total", map[string]string{"method": "getaccount"}, 1},
+		{"tron_client_request_duration_seconds", map[string]string{"method": "getaccount"}, 2},
+		{"tron_client_in_flight_requests", map[string]string{"method": "getaccount"}, 0},
+		{"tron_client_requests_total", map[string]string{"method": "broadcasttransaction", "code": "SIGERROR"}, 1},
+		{"tron_client_errors_total", map[string]string{"method": "broadcasttransaction", "code": "SIGERROR"}, 1},
+	} {
+		if got := value(t, families, tc.name, tc.labels); got != tc.want {
+			t.Fatalf("%s%v = %v, want %v", tc.name, tc.labels, got, tc.want)
+		}
+	}
+}
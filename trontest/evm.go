@@ -0,0 +1,198 @@
+package trontest
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+const (
+	// energyPrice converts fee_limit into an energy (gas) limit. Energy is
+	// not billed; fee_limit only caps execution.
+	energyPrice = 100
+	maxEnergy   = 100_000_000
+)
+
+type execResult struct {
+	ret        []byte
+	energyUsed int64
+	err        error
+	logs       []*types.Log
+}
+
+// contractRet maps an EVM error to the contractRet reported by java-tron.
+func (r *execResult) contractRet() string {
+	switch {
+	case r.err == nil:
+		return tron.TxStatusSuccess
+	case errors.Is(r.err, vm.ErrExecutionReverted):
+		return "REVERT"
+	case errors.Is(r.err, vm.ErrOutOfGas):
+		return "OUT_OF_ENERGY"
+	case errors.Is(r.err, vm.ErrInvalidJump):
+		return "BAD_JUMP_DESTINATION"
+	}
+
+	var underflow *vm.ErrStackUnderflow
+	var invalidOp *vm.ErrInvalidOpCode
+	switch {
+	case errors.As(r.err, &underflow):
+		return "STACK_TOO_SMALL"
+	case errors.As(r.err, &invalidOp):
+		return "ILLEGAL_OPERATION"
+	}
+	return "UNKNOWN"
+}
+
+func newStateDB() *state.StateDB {
+	db := state.NewDatabase(triedb.NewDatabase(rawdb.NewMemoryDatabase(), nil), nil)
+	st, err := state.New(types.EmptyRootHash, db)
+	if err != nil {
+		panic(err)
+	}
+	return st
+}
+
+func energyLimit(feeLimit int64) uint64 {
+	if feeLimit <= 0 {
+		return maxEnergy
+	}
+	return uint64(min(feeLimit/energyPrice, maxEnergy))
+}
+
+// newEVM prepares an EVM over st in the context of block b. TRX balances are
+// owned by the node, so they are copied into st before every execution.
+func (n *Node) newEVM(st *state.StateDB, accounts map[tron.Address]account, b *block) *vm.EVM {
+	for addr, acc := range accounts {
+		st.SetBalance(addr.EVM(), uint256.NewInt(uint64(acc.Balance)), tracing.BalanceChangeUnspecified)
+	}
+
+	blockCtx := vm.BlockContext{
+		CanTransfer: func(db vm.StateDB, addr common.Address, amount *uint256.Int) bool {
+			return db.GetBalance(addr).Cmp(amount) >= 0
+		},
+		Transfer: func(db vm.StateDB, from, to common.Address, amount *uint256.Int) {
+			db.SubBalance(from, amount, tracing.BalanceChangeTransfer)
+			db.AddBalance(to, amount, tracing.BalanceChangeTransfer)
+		},
+		GetHash: func(num uint64) common.Hash {
+			if num >= uint64(len(n.blocks)) {
+				return common.Hash{}
+			}
+			return common.HexToHash(n.blocks[num].ID)
+		},
+		Coinbase:    witnessAddress.EVM(),
+		GasLimit:    maxEnergy,
+		BlockNumber: big.NewInt(b.Number),
+		Time:        uint64(b.Timestamp / 1000),
+		Difficulty:  new(big.Int),
+		BaseFee:     new(big.Int),
+		BlobBaseFee: new(big.Int),
+		Random:      &common.Hash{},
+	}
+	return vm.NewEVM(blockCtx, st, params.MergedTestChainConfig, vm.Config{})
+}
+
+// execute calls contract on st. st is modified, so constant calls pass a copy.
+func (n *Node) execute(
+	st *state.StateDB,
+	accounts map[tron.Address]account,
+	b *block,
+	txHash common.Hash,
+	owner tron.Address,
+	contract tron.Address,
+	input []byte,
+	energy uint64,
+) *execResult {
+	evm := n.newEVM(st, accounts, b)
+	rules := evm.ChainConfig().Rules(evm.Context.BlockNumber, true, evm.Context.Time)
+	dst := contract.EVM()
+
+	st.SetTxContext(txHash, 0)
+	st.Prepare(rules, owner.EVM(), evm.Context.Coinbase, &dst, vm.ActivePrecompiles(rules), nil)
+	evm.SetTxContext(vm.TxContext{Origin: owner.EVM(), GasPrice: new(big.Int)})
+
+	ret, left, err := evm.Call(owner.EVM(), dst, input, energy, new(uint256.Int))
+	st.Finalise(true)
+
+	return &execResult{
+		ret:        ret,
+		energyUsed: int64(energy - left),
+		err:        err,
+		logs:       st.GetLogs(txHash, uint64(b.Number), common.Hash{}, evm.Context.Time),
+	}
+}
+
+// callData builds the input from function_selector and parameter, or takes
+// data as the full calldata when set.
+func callData(selector, parameter, data string) ([]byte, error) {
+	if data != "" {
+		return hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	}
+	if selector == "" {
+		return nil, errors.New("function_selector or data is required")
+	}
+
+	param, err := hex.DecodeString(strings.TrimPrefix(parameter, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("decode parameter: %w", err)
+	}
+	return append(crypto.Keccak256([]byte(selector))[:4], param...), nil
+}
+
+// Deploy runs creationCode (with any ABI-encoded constructor arguments
+// appended) as owner and returns the new contract address. Like Fund, the
+// deployment is genesis state: it is visible on both the full and solidity
+// endpoints at once.
+func (n *Node) Deploy(owner tron.Address, creationCode []byte) (tron.Address, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.deploySeq++
+	salt := uint256.NewInt(uint64(n.deploySeq))
+
+	deploy := func(st *state.StateDB, accounts map[tron.Address]account, b *block) (common.Address, error) {
+		evm := n.newEVM(st, accounts, b)
+		_, addr, _, err := evm.Create2(owner.EVM(), creationCode, maxEnergy, new(uint256.Int), salt)
+		st.Finalise(true)
+		return addr, err
+	}
+
+	addr, err := deploy(n.stateDB, n.accounts, n.head())
+	if err != nil {
+		return tron.Address{}, fmt.Errorf("deploy: %w", err)
+	}
+	for _, b := range n.blocks {
+		if _, err := deploy(b.stateDB, b.accounts, b); err != nil {
+			return tron.Address{}, fmt.Errorf("deploy at block %d: %w", b.Number, err)
+		}
+	}
+	return tron.AddressFromEVM(addr), nil
+}
+
+// SetCode installs runtime bytecode at addr as genesis state.
+func (n *Node) SetCode(addr tron.Address, runtimeCode []byte) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.stateDB.SetCode(addr.EVM(), runtimeCode, tracing.CodeChangeUnspecified)
+	n.stateDB.Finalise(true)
+	for _, b := range n.blocks {
+		b.stateDB.SetCode(addr.EVM(), runtimeCode, tracing.CodeChangeUnspecified)
+		b.stateDB.Finalise(true)
+	}
+}
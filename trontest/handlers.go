@@ -0,0 +1,810 @@
+package trontest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/state"
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+const (
+	transferContractType      = "TransferContract"
+	transferAssetContractType = "TransferAssetContract"
+	triggerSmartContractType  = "TriggerSmartContract"
+)
+
+var contractTypes = map[string]tron.ContractType{
+	transferContractType:      tron.TransferContract,
+	transferAssetContractType: tron.TransferAssetContract,
+	triggerSmartContractType:  tron.TriggerSmartContract,
+}
+
+// view is the chain as seen by an endpoint: the head for /wallet and the
+// solidified block for /walletsolidity.
+type view struct {
+	solid bool
+}
+
+type handlerFunc func(n *Node, v view, body []byte) (any, error)
+
+type endpoint struct {
+	handle handlerFunc
+	solid  bool
+}
+
+var endpoints = map[string]endpoint{
+	"getnowblock":             {handle: (*Node).getNowBlock, solid: true},
+	"getblockbynum":           {handle: (*Node).getBlockByNum, solid: true},
+	"getblockbyid":            {handle: (*Node).getBlockByID, solid: true},
+	"gettransactionbyid":      {handle: (*Node).getTransactionByID, solid: true},
+	"gettransactioninfobyid":  {handle: (*Node).getTransactionInfoByID, solid: true},
+	"getaccount":              {handle: (*Node).getAccount, solid: true},
+	"getassetissuebyid":       {handle: (*Node).getAssetIssueByID, solid: true},
+	"triggerconstantcontract": {handle: (*Node).triggerConstantContract, solid: true},
+	"triggersmartcontract":    {handle: (*Node).triggerSmartContract},
+	"getnodeinfo":             {handle: (*Node).getNodeInfo, solid: true},
+	"createtransaction":       {handle: (*Node).createTransaction},
+	"transferasset":           {handle: (*Node).transferAsset},
+	"broadcasttransaction":    {handle: (*Node).broadcastTransaction},
+}
+
+// validationError is reported the way java-tron reports a rejected request:
+// HTTP 200 with an "Error" field.
+type validationError struct {
+	msg string
+}
+
+func (e *validationError) Error() string {
+	return e.msg
+}
+
+func (n *Node) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /wallet/{method}", func(w http.ResponseWriter, r *http.Request) {
+		n.serve(w, r, view{})
+	})
+	mux.HandleFunc("POST /walletsolidity/{method}", func(w http.ResponseWriter, r *http.Request) {
+		n.serve(w, r, view{solid: true})
+	})
+	mux.HandleFunc("POST /jsonrpc", n.serveJSONRPC)
+	return mux
+}
+
+func (n *Node) serve(w http.ResponseWriter, r *http.Request, v view) {
+	ep, ok := endpoints[r.PathValue("method")]
+	if !ok || (v.solid && !ep.solid) {
+		http.NotFound(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	n.mu.Lock()
+	out, err := ep.handle(n, v, body)
+	n.mu.Unlock()
+
+	var verr *validationError
+	switch {
+	case errors.As(err, &verr):
+		out = map[string]string{"Error": verr.msg}
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func decodeReq(body []byte, req any) error {
+	if err := json.Unmarshal(body, req); err != nil {
+		return fmt.Errorf("decode request: %w", err)
+	}
+	return nil
+}
+
+func (n *Node) tip(v view) *block {
+	if v.solid {
+		return n.solid()
+	}
+	return n.head()
+}
+
+type blockHeaderRawJSON struct {
+	Number         int64        `json:"number,omitempty"`
+	TxTrieRoot     string       `json:"txTrieRoot"`
+	WitnessAddress tron.Address `json:"witness_address"`
+	ParentHash     string       `json:"parentHash,omitempty"`
+	Timestamp      int64        `json:"timestamp"`
+}
+
+type blockJSON struct {
+	BlockID     string `json:"blockID"`
+	BlockHeader struct {
+		RawData blockHeaderRawJSON `json:"raw_data"`
+	} `json:"block_header"`
+	Transactions []txJSON `json:"transactions,omitempty"`
+}
+
+type txRet struct {
+	ContractRet string `json:"contractRet"`
+}
+
+type txJSON struct {
+	Ret []txRet `json:"ret,omitempty"`
+	tron.TronTx
+}
+
+var witnessAddress = NewKey("witness").Address
+
+func (n *Node) blockJSON(b *block) blockJSON {
+	var out blockJSON
+	out.BlockID = b.ID
+	out.BlockHeader.RawData = blockHeaderRawJSON{
+		Number:         b.Number,
+		TxTrieRoot:     strings.Repeat("0", 64),
+		WitnessAddress: witnessAddress,
+		ParentHash:     b.Parent,
+		Timestamp:      b.Timestamp,
+	}
+	for _, id := range b.TxIDs {
+		out.Transactions = append(out.Transactions, n.txJSON(n.txs[id]))
+	}
+	return out
+}
+
+func (n *Node) txJSON(rec *txRecord) txJSON {
+	ret := tron.TxStatusSuccess
+	if rec.exec != nil {
+		ret = rec.exec.contractRet()
+	}
+	return txJSON{
+		Ret:    []txRet{{ContractRet: ret}},
+		TronTx: rec.tx,
+	}
+}
+
+func (n *Node) getNowBlock(v view, _ []byte) (any, error) {
+	return n.blockJSON(n.tip(v)), nil
+}
+
+func (n *Node) getBlockByNum(v view, body []byte) (any, error) {
+	var req tron.GetBlockByNumReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+	if req.Num < 0 || req.Num > n.tip(v).Number {
+		return struct{}{}, nil
+	}
+	return n.blockJSON(n.blocks[req.Num]), nil
+}
+
+func (n *Node) getBlockByID(v view, body []byte) (any, error) {
+	var req tron.GetBlockByIDReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+	b := n.blockByID(req.Value)
+	if b == nil || b.Number > n.tip(v).Number {
+		return struct{}{}, nil
+	}
+	return n.blockJSON(b), nil
+}
+
+// confirmedTx returns the transaction if it is in a block visible to v.
+func (n *Node) confirmedTx(v view, txID string) *txRecord {
+	rec, ok := n.txs[strings.ToLower(txID)]
+	if !ok || rec.block == nil || rec.block.Number > n.tip(v).Number {
+		return nil
+	}
+	return rec
+}
+
+func (n *Node) getTransactionByID(v view, body []byte) (any, error) {
+	var req tron.GetTransactionByIDReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+	rec := n.confirmedTx(v, req.Value)
+	if rec == nil {
+		return struct{}{}, nil
+	}
+	return n.txJSON(rec), nil
+}
+
+type logJSON struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+type txInfoJSON struct {
+	ID              string        `json:"id"`
+	BlockNumber     int64         `json:"blockNumber"`
+	BlockTimeStamp  int64         `json:"blockTimeStamp"`
+	ContractResult  []string      `json:"contractResult"`
+	ContractAddress *tron.Address `json:"contract_address,omitempty"`
+	Receipt         struct {
+		EnergyUsageTotal int64  `json:"energy_usage_total,omitempty"`
+		NetUsage         int64  `json:"net_usage"`
+		Result           string `json:"result,omitempty"`
+	} `json:"receipt"`
+	Log        []logJSON `json:"log,omitempty"`
+	Result     string    `json:"result,omitempty"`
+	ResMessage string    `json:"resMessage,omitempty"`
+}
+
+func (n *Node) getTransactionInfoByID(v view, body []byte) (any, error) {
+	var req tron.GetTransactionInfoByIDReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+	rec := n.confirmedTx(v, req.Value)
+	if rec == nil {
+		return struct{}{}, nil
+	}
+
+	out := txInfoJSON{
+		ID:             rec.tx.TxID,
+		BlockNumber:    rec.block.Number,
+		BlockTimeStamp: rec.block.Timestamp,
+		ContractResult: []string{""},
+	}
+	out.Receipt.NetUsage = rec.netUsage
+
+	if exec := rec.exec; exec != nil {
+		contract := rec.contract
+		out.ContractAddress = &contract
+		out.ContractResult = []string{hex.EncodeToString(exec.ret)}
+		out.Receipt.EnergyUsageTotal = exec.energyUsed
+		out.Receipt.Result = exec.contractRet()
+		if exec.err != nil {
+			out.Result = "FAILED"
+			out.ResMessage = hex.EncodeToString([]byte(exec.err.Error()))
+		}
+		for _, l := range exec.logs {
+			entry := logJSON{
+				Address: hex.EncodeToString(l.Address.Bytes()),
+				Data:    hex.EncodeToString(l.Data),
+			}
+			for _, t := range l.Topics {
+				entry.Topics = append(entry.Topics, hex.EncodeToString(t.Bytes()))
+			}
+			out.Log = append(out.Log, entry)
+		}
+	}
+	return out, nil
+}
+
+type accountJSON struct {
+	Address    tron.Address `json:"address"`
+	Balance    int64        `json:"balance,omitempty"`
+	CreateTime int64        `json:"create_time"`
+
+	Owner   *tron.Permission  `json:"owner_permission,omitempty"`
+	Actives []tron.Permission `json:"active_permission,omitempty"`
+	AssetV2 []assetJSON       `json:"assetV2,omitempty"`
+}
+
+type assetJSON struct {
+	Key   string `json:"key"`
+	Value int64  `json:"value"`
+}
+
+func (n *Node) getAccount(v view, body []byte) (any, error) {
+	var req tron.GetAccountReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+
+	accounts := n.accounts
+	if v.solid {
+		accounts = n.solid().accounts
+	}
+	acc, ok := accounts[req.Address]
+	if !ok {
+		return struct{}{}, nil
+	}
+	out := accountJSON{Address: req.Address, Balance: acc.Balance, CreateTime: acc.CreateTime}
+	if acc.Permissions != nil {
+		out.Owner = acc.Permissions.Owner
+		out.Actives = acc.Permissions.Actives
+	}
+	for _, id := range slices.Sorted(maps.Keys(acc.Assets)) {
+		out.AssetV2 = append(out.AssetV2, assetJSON{Key: id, Value: acc.Assets[id]})
+	}
+	return out, nil
+}
+
+func (n *Node) getAssetIssueByID(_ view, body []byte) (any, error) {
+	var req tron.GetAssetIssueByIDReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+	asset, ok := n.assets[req.Value]
+	if !ok {
+		return struct{}{}, nil
+	}
+	return asset, nil
+}
+
+func (n *Node) getNodeInfo(_ view, _ []byte) (any, error) {
+	head, solid := n.head(), n.solid()
+	return map[string]any{
+		"block":               fmt.Sprintf("Num:%d,ID:%s", head.Number, head.ID),
+		"solidityBlock":       fmt.Sprintf("Num:%d,ID:%s", solid.Number, solid.ID),
+		"currentConnectCount": 0,
+		"activeConnectCount":  0,
+	}, nil
+}
+
+type contractJSON struct {
+	Parameter struct {
+		Value   json.RawMessage `json:"value"`
+		TypeURL string          `json:"type_url"`
+	} `json:"parameter"`
+	Type         string `json:"type"`
+	PermissionID int    `json:"Permission_id,omitempty"`
+}
+
+type rawDataJSON struct {
+	Contract      []contractJSON `json:"contract"`
+	RefBlockBytes string         `json:"ref_block_bytes"`
+	RefBlockHash  string         `json:"ref_block_hash"`
+	Expiration    int64          `json:"expiration"`
+	FeeLimit      int64          `json:"fee_limit,omitempty"`
+	Timestamp     int64          `json:"timestamp"`
+}
+
+type transferJSON struct {
+	Amount       int64        `json:"amount"`
+	OwnerAddress tron.Address `json:"owner_address"`
+	ToAddress    tron.Address `json:"to_address"`
+}
+
+type transferAssetJSON struct {
+	Amount       int64        `json:"amount"`
+	AssetName    string       `json:"asset_name"`
+	OwnerAddress tron.Address `json:"owner_address"`
+	ToAddress    tron.Address `json:"to_address"`
+}
+
+func (n *Node) validateTransferAsset(t transferAssetJSON) error {
+	switch {
+	case t.Amount <= 0:
+		return &validationError{"Amount must be greater than 0."}
+	case t.OwnerAddress.IsZero() || t.ToAddress.IsZero():
+		return &validationError{"Invalid address."}
+	case t.OwnerAddress == t.ToAddress:
+		return &validationError{"Cannot transfer asset to yourself."}
+	}
+	if _, ok := n.assets[t.AssetName]; !ok {
+		return &validationError{"No asset!"}
+	}
+
+	owner, ok := n.accounts[t.OwnerAddress]
+	if !ok {
+		return &validationError{"No owner account!"}
+	}
+	if owner.Assets[t.AssetName] < t.Amount {
+		return &validationError{"assetBalance is not sufficient."}
+	}
+	return nil
+}
+
+func (n *Node) validateTransfer(t transferJSON) error {
+	switch {
+	case t.Amount <= 0:
+		return &validationError{"Amount must be greater than 0."}
+	case t.OwnerAddress.IsZero() || t.ToAddress.IsZero():
+		return &validationError{"Invalid address."}
+	case t.OwnerAddress == t.ToAddress:
+		return &validationError{"Cannot transfer TRX to yourself."}
+	}
+
+	owner, ok := n.accounts[t.OwnerAddress]
+	if !ok {
+		return &validationError{"Validate TransferContract error, no OwnerAccount."}
+	}
+	if owner.Balance < t.Amount {
+		return &validationError{"Validate TransferContract error, balance is not sufficient."}
+	}
+	return nil
+}
+
+// newTransaction builds an unsigned single-contract transaction whose
+// raw_data_hex is the protobuf encoding of raw_data.
+func (n *Node) newTransaction(contractType string, value any, permissionID int, feeLimit int64) (tron.TronTx, error) {
+	v, err := json.Marshal(value)
+	if err != nil {
+		return tron.TronTx{}, err
+	}
+
+	head := n.head()
+	n.txSeq++
+
+	var c contractJSON
+	c.Parameter.Value = v
+	c.Parameter.TypeURL = "type.googleapis.com/protocol." + contractType
+	c.Type = contractType
+	c.PermissionID = permissionID
+
+	rd := rawDataJSON{
+		Contract:      []contractJSON{c},
+		RefBlockBytes: fmt.Sprintf("%04x", head.Number&0xffff),
+		RefBlockHash:  head.ID[16:32],
+		Expiration:    head.Timestamp + defaultExpiration.Milliseconds(),
+		FeeLimit:      feeLimit,
+		Timestamp:     head.Timestamp + n.txSeq,
+	}
+	rawJSON, err := json.Marshal(rd)
+	if err != nil {
+		return tron.TronTx{}, err
+	}
+	raw, err := encodeRawData(rd)
+	if err != nil {
+		return tron.TronTx{}, err
+	}
+
+	h := sha256.Sum256(raw)
+	return tron.TronTx{
+		Visible:    true,
+		TxID:       hex.EncodeToString(h[:]),
+		RawData:    rawJSON,
+		RawDataHex: hex.EncodeToString(raw),
+	}, nil
+}
+
+func (n *Node) createTransaction(_ view, body []byte) (any, error) {
+	var req tron.CreateTransactionReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+
+	transfer := transferJSON{Amount: req.Amount, OwnerAddress: req.OwnerAddress, ToAddress: req.ToAddress}
+	if err := n.validateTransfer(transfer); err != nil {
+		return nil, err
+	}
+	return n.newTransaction(transferContractType, transfer, req.PermissionID, 0)
+}
+
+func (n *Node) transferAsset(_ view, body []byte) (any, error) {
+	var req tron.TransferAssetReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+
+	transfer := transferAssetJSON{
+		Amount:       req.Amount,
+		AssetName:    req.AssetName,
+		OwnerAddress: req.OwnerAddress,
+		ToAddress:    req.ToAddress,
+	}
+	if err := n.validateTransferAsset(transfer); err != nil {
+		return nil, err
+	}
+	return n.newTransaction(transferAssetContractType, transfer, req.PermissionID, 0)
+}
+
+type triggerReq struct {
+	OwnerAddress    tron.Address `json:"owner_address"`
+	ContractAddress tron.Address `json:"contract_address"`
+	Function        string       `json:"function_selector"`
+	Parameter       string       `json:"parameter"`
+	Data            string       `json:"data"`
+	CallValue       int64        `json:"call_value"`
+	FeeLimit        int64        `json:"fee_limit"`
+	PermissionID    int          `json:"Permission_id"`
+}
+
+type triggerJSON struct {
+	OwnerAddress    tron.Address `json:"owner_address"`
+	ContractAddress tron.Address `json:"contract_address"`
+	Data            string       `json:"data"`
+}
+
+type triggerResultJSON struct {
+	Result  bool   `json:"result,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+func (n *Node) validateTrigger(st *state.StateDB, req triggerReq) ([]byte, error) {
+	if req.CallValue != 0 {
+		return nil, &validationError{"call_value is not supported by trontest"}
+	}
+	if st.GetCodeSize(req.ContractAddress.EVM()) == 0 {
+		return nil, &validationError{"No contract or not a smart contract"}
+	}
+
+	input, err := callData(req.Function, req.Parameter, req.Data)
+	if err != nil {
+		return nil, &validationError{err.Error()}
+	}
+	return input, nil
+}
+
+// triggerConstantContract executes the call against a copy of the state at
+// the tip of v, so it never changes the chain.
+func (n *Node) triggerConstantContract(v view, body []byte) (any, error) {
+	var req triggerReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+
+	b := n.tip(v)
+	st, accounts := n.stateDB, n.accounts
+	if v.solid {
+		st, accounts = b.stateDB, b.accounts
+	}
+
+	input, err := n.validateTrigger(st, req)
+	if err != nil {
+		var verr *validationError
+		if errors.As(err, &verr) {
+			return map[string]any{"result": triggerResultJSON{Code: "CONTRACT_VALIDATE_ERROR", Message: verr.msg}}, nil
+		}
+		return nil, err
+	}
+
+	exec := n.execute(st.Copy(), accounts, b, common.Hash{}, req.OwnerAddress, req.ContractAddress, input, energyLimit(req.FeeLimit))
+	result := triggerResultJSON{Result: true}
+	if exec.err != nil {
+		result = triggerResultJSON{Code: "CONTRACT_EXE_ERROR", Message: exec.err.Error()}
+	}
+	return map[string]any{
+		"result":          result,
+		"energy_used":     exec.energyUsed,
+		"constant_result": []string{hex.EncodeToString(exec.ret)},
+	}, nil
+}
+
+func (n *Node) triggerSmartContract(_ view, body []byte) (any, error) {
+	var req triggerReq
+	if err := decodeReq(body, &req); err != nil {
+		return nil, err
+	}
+
+	input, err := n.validateTrigger(n.stateDB, req)
+	if err != nil {
+		var verr *validationError
+		if errors.As(err, &verr) {
+			return map[string]any{"result": triggerResultJSON{Code: "CONTRACT_VALIDATE_ERROR", Message: verr.msg}}, nil
+		}
+		return nil, err
+	}
+	if _, ok := n.accounts[req.OwnerAddress]; !ok {
+		return map[string]any{"result": triggerResultJSON{Code: "CONTRACT_VALIDATE_ERROR", Message: "Account does not exist"}}, nil
+	}
+
+	tx, err := n.newTransaction(triggerSmartContractType, triggerJSON{
+		OwnerAddress:    req.OwnerAddress,
+		ContractAddress: req.ContractAddress,
+		Data:            hex.EncodeToString(input),
+	}, req.PermissionID, req.FeeLimit)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"result":      triggerResultJSON{Result: true},
+		"transaction": tx,
+	}, nil
+}
+
+func broadcastError(txID, code, msg string) *tron.BroadcastResp {
+	return &tron.BroadcastResp{TxID: txID, Code: code, Message: msg}
+}
+
+func (n *Node) broadcastTransaction(_ view, body []byte) (any, error) {
+	var tx tron.TronTx
+	if err := decodeReq(body, &tx); err != nil {
+		return nil, err
+	}
+
+	raw, err := hex.DecodeString(strings.TrimPrefix(tx.RawDataHex, "0x"))
+	if err != nil {
+		return broadcastError(tx.TxID, "OTHER_ERROR", "invalid raw_data_hex"), nil
+	}
+	h := sha256.Sum256(raw)
+	txID := hex.EncodeToString(h[:])
+
+	// raw_data is only trusted once it is known to encode to the signed bytes.
+	var rd rawDataJSON
+	if err := json.Unmarshal(tx.RawData, &rd); err != nil || len(rd.Contract) != 1 {
+		return broadcastError(txID, "OTHER_ERROR", "raw_data is not a single-contract transaction"), nil
+	}
+	if enc, err := encodeRawData(rd); err != nil || !bytes.Equal(enc, raw) {
+		return broadcastError(txID, "OTHER_ERROR", "raw_data does not match raw_data_hex"), nil
+	}
+	if _, dup := n.txs[txID]; dup {
+		return broadcastError(txID, "DUP_TRANSACTION_ERROR", "dup transaction"), nil
+	}
+	if rd.Expiration <= n.head().Timestamp {
+		return broadcastError(txID, "TRANSACTION_EXPIRATION_ERROR", "transaction expired"), nil
+	}
+	if !n.tapos(rd) {
+		return broadcastError(txID, "TAPOS_ERROR", "ref block not found"), nil
+	}
+
+	c := rd.Contract[0]
+	if _, ok := contractTypes[c.Type]; !ok {
+		return broadcastError(txID, "CONTRACT_VALIDATE_ERROR", "unsupported contract type "+c.Type), nil
+	}
+	var owner struct {
+		OwnerAddress tron.Address `json:"owner_address"`
+	}
+	if err := json.Unmarshal(c.Parameter.Value, &owner); err != nil {
+		return broadcastError(txID, "CONTRACT_VALIDATE_ERROR", err.Error()), nil
+	}
+
+	signers, err := tron.VerifyTransaction(body)
+	if err != nil {
+		return broadcastError(txID, "SIGERROR", err.Error()), nil
+	}
+	if err := n.checkSignWeight(owner.OwnerAddress, c, signers); err != nil {
+		return broadcastError(txID, "SIGERROR", err.Error()), nil
+	}
+
+	tx.TxID = txID
+	rec := &txRecord{tx: tx, netUsage: int64(len(raw) + 65*len(tx.Signature))}
+
+	switch c.Type {
+	case transferContractType:
+		err = n.applyTransfer(c.Parameter.Value, rd)
+	case transferAssetContractType:
+		err = n.applyTransferAsset(c.Parameter.Value, rd)
+	case triggerSmartContractType:
+		err = n.applyTrigger(c.Parameter.Value, rd, rec)
+	}
+	if err != nil {
+		return broadcastError(txID, "CONTRACT_VALIDATE_ERROR", err.Error()), nil
+	}
+
+	n.txs[txID] = rec
+	n.pending = append(n.pending, txID)
+	if n.autoBlock {
+		n.produceBlock()
+	}
+
+	return &tron.BroadcastResp{Result: true, TxID: txID}, nil
+}
+
+// checkSignWeight applies java-tron's multi-signature rules: every signer must
+// hold a key in the permission the contract names, no signer may sign twice,
+// and the summed key weights must reach the threshold.
+func (n *Node) checkSignWeight(owner tron.Address, c contractJSON, signers []string) error {
+	perms := n.accounts[owner].Permissions
+	if perms == nil {
+		perms = &tron.AccountPermissions{Owner: &tron.Permission{
+			Threshold: 1,
+			Keys:      []tron.PermissionKey{{Address: owner, Weight: 1}},
+		}}
+	}
+
+	perm, err := perms.Permission(c.PermissionID)
+	if err != nil {
+		return err
+	}
+	if c.PermissionID != tron.OwnerPermissionID {
+		ok, err := tron.PermissionAllows(perm.Operations, contractTypes[c.Type])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("permission denied: %s is not allowed by permission %d", c.Type, c.PermissionID)
+		}
+	}
+
+	var weight int64
+	seen := make(map[string]bool, len(signers))
+	for _, s := range signers {
+		if seen[s] {
+			return fmt.Errorf("%s has signed twice", s)
+		}
+		seen[s] = true
+
+		i := slices.IndexFunc(perm.Keys, func(k tron.PermissionKey) bool { return k.Address.String() == s })
+		if i < 0 {
+			return fmt.Errorf("%s is not contained in permission %d", s, c.PermissionID)
+		}
+		weight += perm.Keys[i].Weight
+	}
+	if weight < perm.Threshold {
+		return fmt.Errorf("signature weight %d is below threshold %d", weight, perm.Threshold)
+	}
+	return nil
+}
+
+func (n *Node) tapos(rd rawDataJSON) bool {
+	for _, b := range slices.Backward(n.blocks) {
+		if fmt.Sprintf("%04x", b.Number&0xffff) == rd.RefBlockBytes && b.ID[16:32] == rd.RefBlockHash {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) applyTransfer(value json.RawMessage, rd rawDataJSON) error {
+	var t transferJSON
+	if err := json.Unmarshal(value, &t); err != nil {
+		return err
+	}
+	if err := n.validateTransfer(t); err != nil {
+		return err
+	}
+
+	owner := n.accounts[t.OwnerAddress]
+	owner.Balance -= t.Amount
+	n.accounts[t.OwnerAddress] = owner
+
+	to, ok := n.accounts[t.ToAddress]
+	if !ok {
+		to.CreateTime = rd.Timestamp
+	}
+	to.Balance += t.Amount
+	n.accounts[t.ToAddress] = to
+	return nil
+}
+
+func (n *Node) applyTransferAsset(value json.RawMessage, rd rawDataJSON) error {
+	var t transferAssetJSON
+	if err := json.Unmarshal(value, &t); err != nil {
+		return err
+	}
+	if err := n.validateTransferAsset(t); err != nil {
+		return err
+	}
+
+	owner := n.accounts[t.OwnerAddress]
+	owner.Assets = maps.Clone(owner.Assets)
+	owner.Assets[t.AssetName] -= t.Amount
+	n.accounts[t.OwnerAddress] = owner
+
+	to, ok := n.accounts[t.ToAddress]
+	if !ok {
+		to.CreateTime = rd.Timestamp
+	}
+	to.Assets = maps.Clone(to.Assets)
+	if to.Assets == nil {
+		to.Assets = make(map[string]int64)
+	}
+	to.Assets[t.AssetName] += t.Amount
+	n.accounts[t.ToAddress] = to
+	return nil
+}
+
+// applyTrigger executes the call on the head state. A reverted call is still
+// accepted and included, as on a real node; the outcome is in rec.exec.
+func (n *Node) applyTrigger(value json.RawMessage, rd rawDataJSON, rec *txRecord) error {
+	var t triggerJSON
+	if err := json.Unmarshal(value, &t); err != nil {
+		return err
+	}
+	if _, ok := n.accounts[t.OwnerAddress]; !ok {
+		return errors.New("Account does not exist")
+	}
+	input, err := n.validateTrigger(n.stateDB, triggerReq{ContractAddress: t.ContractAddress, Data: t.Data})
+	if err != nil {
+		return err
+	}
+
+	txHash := common.HexToHash(rec.tx.TxID)
+	rec.contract = t.ContractAddress
+	rec.exec = n.execute(n.stateDB, n.accounts, n.head(), txHash, t.OwnerAddress, t.ContractAddress, input, energyLimit(rd.FeeLimit))
+	return nil
+}
@@ -0,0 +1,186 @@
+package trontest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxLogBlockRange mirrors java-tron's default node.jsonrpc.maxBlockRange.
+const maxLogBlockRange = 5000
+
+type rpcRequest struct {
+	JSONRPC string            `json:"jsonrpc"`
+	ID      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return e.Message
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcHandler func(n *Node, params []json.RawMessage) (any, error)
+
+// rpcMethods is the subset of the /jsonrpc API the fake node serves.
+var rpcMethods = map[string]rpcHandler{
+	"eth_blockNumber": (*Node).ethBlockNumber,
+	"eth_getLogs":     (*Node).ethGetLogs,
+}
+
+func (n *Node) serveJSONRPC(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp := rpcResponse{JSONRPC: "2.0", ID: req.ID}
+	handle, ok := rpcMethods[req.Method]
+	if !ok {
+		resp.Error = &rpcError{Code: -32601, Message: fmt.Sprintf("the method %s does not exist/is not available", req.Method)}
+	} else {
+		n.mu.Lock()
+		resp.Result, err = handle(n, req.Params)
+		n.mu.Unlock()
+		if err != nil {
+			rerr, ok := err.(*rpcError)
+			if !ok {
+				rerr = &rpcError{Code: -32602, Message: err.Error()}
+			}
+			resp.Error = rerr
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (n *Node) ethBlockNumber(_ []json.RawMessage) (any, error) {
+	return hexutil.Uint64(n.head().Number), nil
+}
+
+// logFilter is the eth_getLogs filter object. Unlike Ethereum nodes, address
+// must be given as a list.
+type logFilter struct {
+	BlockHash *common.Hash     `json:"blockHash"`
+	FromBlock string           `json:"fromBlock"`
+	ToBlock   string           `json:"toBlock"`
+	Address   []common.Address `json:"address"`
+	Topics    [][]common.Hash  `json:"topics"`
+}
+
+// blockTag resolves a fromBlock or toBlock value. Finalized is the solidified
+// block, as on java-tron.
+func (n *Node) blockTag(tag string) (int64, error) {
+	switch tag {
+	case "", "latest", "pending":
+		return n.head().Number, nil
+	case "earliest":
+		return 0, nil
+	case "finalized":
+		return n.solid().Number, nil
+	}
+	num, err := hexutil.DecodeUint64(tag)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block number %q", tag)
+	}
+	return int64(num), nil
+}
+
+func (n *Node) ethGetLogs(params []json.RawMessage) (any, error) {
+	if len(params) != 1 {
+		return nil, fmt.Errorf("eth_getLogs takes one filter, got %d params", len(params))
+	}
+	var f logFilter
+	if err := json.Unmarshal(params[0], &f); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	var blocks []*block
+	if f.BlockHash != nil {
+		if b := n.blockByID(strings.TrimPrefix(f.BlockHash.Hex(), "0x")); b != nil {
+			blocks = []*block{b}
+		}
+	} else {
+		from, err := n.blockTag(f.FromBlock)
+		if err != nil {
+			return nil, err
+		}
+		to, err := n.blockTag(f.ToBlock)
+		if err != nil {
+			return nil, err
+		}
+		if from > to {
+			return nil, errors.New("please verify: fromBlock <= toBlock")
+		}
+		if to-from >= maxLogBlockRange {
+			return nil, &rpcError{Code: -32005, Message: fmt.Sprintf("exceed max block range: %d", maxLogBlockRange)}
+		}
+		blocks = n.blocks[min(from, int64(len(n.blocks))):min(to+1, int64(len(n.blocks)))]
+	}
+
+	out := []*types.Log{}
+	for _, b := range blocks {
+		index := uint(0)
+		for i, id := range b.TxIDs {
+			exec := n.txs[id].exec
+			if exec == nil {
+				continue
+			}
+			for _, l := range exec.logs {
+				if matchLog(l, f) {
+					entry := *l
+					entry.BlockNumber = uint64(b.Number)
+					entry.BlockHash = common.HexToHash(b.ID)
+					entry.TxHash = common.HexToHash(id)
+					entry.TxIndex = uint(i)
+					entry.Index = index
+					out = append(out, &entry)
+				}
+				index++
+			}
+		}
+	}
+	return out, nil
+}
+
+func matchLog(l *types.Log, f logFilter) bool {
+	if len(f.Address) > 0 && !slices.Contains(f.Address, l.Address) {
+		return false
+	}
+	if len(f.Topics) > len(l.Topics) {
+		return false
+	}
+	for i, want := range f.Topics {
+		if len(want) > 0 && !slices.Contains(want, l.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,334 @@
+// Package trontest runs an in-process fake TRON full/solidity node over
+// httptest, with eth_blockNumber and eth_getLogs on /jsonrpc, so code built on
+// tron.Client can be tested offline.
+package trontest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+const (
+	blockInterval      = 3 * time.Second
+	defaultExpiration  = 60 * time.Second
+	defaultGenesisTime = "2024-01-01T00:00:00Z"
+)
+
+type Option func(*Node)
+
+// WithGenesisTime sets the timestamp of block 0; later blocks follow every 3s.
+func WithGenesisTime(t time.Time) Option {
+	return func(n *Node) { n.genesisTime = t }
+}
+
+// WithSolidLag makes the solidity endpoints trail the head by the given
+// number of blocks.
+func WithSolidLag(blocks int64) Option {
+	return func(n *Node) { n.solidLag = blocks }
+}
+
+// WithAutoBlock produces a block after every accepted broadcast instead of
+// waiting for ProduceBlock.
+func WithAutoBlock(auto bool) Option {
+	return func(n *Node) { n.autoBlock = auto }
+}
+
+type account struct {
+	Balance    int64
+	CreateTime int64
+
+	// Nil until SetPermissions; the account is then controlled by its own key.
+	Permissions *tron.AccountPermissions
+	// TRC10 balances by token id. Blocks share the map with the head, so it
+	// is replaced, never modified in place.
+	Assets map[string]int64
+}
+
+type block struct {
+	ID        string
+	Number    int64
+	Timestamp int64
+	Parent    string
+	TxIDs     []string
+	accounts  map[tron.Address]account
+	stateDB   *state.StateDB
+}
+
+type txRecord struct {
+	tx       tron.TronTx
+	netUsage int64
+	block    *block
+
+	// Set for TriggerSmartContract transactions.
+	contract tron.Address
+	exec     *execResult
+}
+
+// Node is a deterministic fake node. State only changes through broadcast
+// transactions, Fund and ProduceBlock, so identical test steps give identical
+// block and transaction ids.
+type Node struct {
+	srv *httptest.Server
+
+	genesisTime time.Time
+	solidLag    int64
+	autoBlock   bool
+
+	mu       sync.Mutex
+	accounts map[tron.Address]account
+	blocks   []*block
+	pending  []string
+	txs      map[string]*txRecord
+	txSeq    int64
+	assets   map[string]tron.AssetIssue
+
+	stateDB   *state.StateDB
+	deploySeq int64
+}
+
+func NewNode(opts ...Option) *Node {
+	genesis, _ := time.Parse(time.RFC3339, defaultGenesisTime)
+	n := &Node{
+		genesisTime: genesis,
+		accounts:    make(map[tron.Address]account),
+		txs:         make(map[string]*txRecord),
+		assets:      make(map[string]tron.AssetIssue),
+		stateDB:     newStateDB(),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	n.blocks = []*block{n.newBlock(0, n.genesisTime.UnixMilli(), "", nil)}
+	n.srv = httptest.NewServer(n.handler())
+	return n
+}
+
+func (n *Node) URL() string {
+	return n.srv.URL
+}
+
+func (n *Node) Close() {
+	n.srv.Close()
+}
+
+func (n *Node) Client(opts ...tron.Option) *tron.Client {
+	return tron.New(n.URL(), opts...)
+}
+
+func (n *Node) SolidClient(opts ...tron.Option) *tron.Client {
+	return tron.NewSolid(n.URL(), opts...)
+}
+
+// Fund credits sun to addr as a genesis allocation: it is visible at once on
+// both the full and solidity endpoints.
+func (n *Node) Fund(addr tron.Address, sun int64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	credit := func(accounts map[tron.Address]account) {
+		acc, ok := accounts[addr]
+		if !ok {
+			acc.CreateTime = n.genesisTime.UnixMilli()
+		}
+		acc.Balance += sun
+		accounts[addr] = acc
+	}
+
+	credit(n.accounts)
+	for _, b := range n.blocks {
+		credit(b.accounts)
+	}
+}
+
+// SetPermissions replaces the owner and active permissions of addr as genesis
+// state, like Fund. Active permissions without an id are numbered from 2 in
+// order.
+func (n *Node) SetPermissions(addr tron.Address, owner tron.Permission, actives ...tron.Permission) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	owner.Type = tron.PermissionTypeOwner
+	owner.ID = tron.OwnerPermissionID
+	actives = slices.Clone(actives)
+	for i := range actives {
+		actives[i].Type = tron.PermissionTypeActive
+		if actives[i].ID == 0 {
+			actives[i].ID = tron.FirstActivePermissionID + i
+		}
+	}
+	perms := &tron.AccountPermissions{Owner: &owner, Actives: actives}
+
+	set := func(accounts map[tron.Address]account) {
+		acc, ok := accounts[addr]
+		if !ok {
+			acc.CreateTime = n.genesisTime.UnixMilli()
+		}
+		acc.Permissions = perms
+		accounts[addr] = acc
+	}
+
+	set(n.accounts)
+	for _, b := range n.blocks {
+		set(b.accounts)
+	}
+}
+
+// firstAssetID is the id java-tron gives the first TRC10 token.
+const firstAssetID = 1000001
+
+// IssueAsset registers a TRC10 token owned by asset.OwnerAddress, credits the
+// owner with its total supply as genesis state, and returns the token id.
+func (n *Node) IssueAsset(asset tron.AssetIssue) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	asset.ID = strconv.Itoa(firstAssetID + len(n.assets))
+	n.assets[asset.ID] = asset
+
+	credit := func(accounts map[tron.Address]account) {
+		acc, ok := accounts[asset.OwnerAddress]
+		if !ok {
+			acc.CreateTime = n.genesisTime.UnixMilli()
+		}
+		acc.Assets = maps.Clone(acc.Assets)
+		if acc.Assets == nil {
+			acc.Assets = make(map[string]int64)
+		}
+		acc.Assets[asset.ID] += asset.TotalSupply
+		accounts[asset.OwnerAddress] = acc
+	}
+
+	credit(n.accounts)
+	for _, b := range n.blocks {
+		credit(b.accounts)
+	}
+	return asset.ID
+}
+
+// AssetBalance returns the head-state balance of a TRC10 token in base units.
+func (n *Node) AssetBalance(addr tron.Address, tokenID string) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.accounts[addr].Assets[tokenID]
+}
+
+// Balance returns the head-state balance of addr in sun.
+func (n *Node) Balance(addr tron.Address) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.accounts[addr].Balance
+}
+
+func (n *Node) BlockNumber() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.head().Number
+}
+
+func (n *Node) SolidBlockNumber() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.solid().Number
+}
+
+// ProduceBlock seals the pending transactions into a new block and returns
+// its number.
+func (n *Node) ProduceBlock() int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.produceBlock()
+}
+
+func (n *Node) ProduceBlocks(count int) int64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	for range count {
+		n.produceBlock()
+	}
+	return n.head().Number
+}
+
+func (n *Node) produceBlock() int64 {
+	head := n.head()
+	b := n.newBlock(head.Number+1, head.Timestamp+blockInterval.Milliseconds(), head.ID, n.pending)
+	n.blocks = append(n.blocks, b)
+
+	for _, id := range n.pending {
+		n.txs[id].block = b
+	}
+	n.pending = nil
+	return b.Number
+}
+
+func (n *Node) newBlock(number, timestamp int64, parent string, txIDs []string) *block {
+	b := &block{
+		Number:    number,
+		Timestamp: timestamp,
+		Parent:    parent,
+		TxIDs:     txIDs,
+		accounts:  maps.Clone(n.accounts),
+		stateDB:   n.stateDB.Copy(),
+	}
+
+	header, _ := json.Marshal(struct {
+		Number    int64    `json:"number"`
+		Timestamp int64    `json:"timestamp"`
+		Parent    string   `json:"parent"`
+		TxIDs     []string `json:"txs"`
+	}{number, timestamp, parent, txIDs})
+	h := sha256.Sum256(header)
+	binary.BigEndian.PutUint64(h[:8], uint64(number))
+	b.ID = hex.EncodeToString(h[:])
+	return b
+}
+
+func (n *Node) head() *block {
+	return n.blocks[len(n.blocks)-1]
+}
+
+func (n *Node) solid() *block {
+	return n.blocks[max(0, int64(len(n.blocks))-1-n.solidLag)]
+}
+
+func (n *Node) blockByID(id string) *block {
+	for _, b := range n.blocks {
+		if b.ID == id {
+			return b
+		}
+	}
+	return nil
+}
+
+// Key is a deterministic test account.
+type Key struct {
+	PrivateKey string
+	Address    tron.Address
+}
+
+// NewKey derives a key from seed, so the same seed always yields the same
+// address.
+func NewKey(seed string) Key {
+	for i := 0; ; i++ {
+		h := sha256.Sum256([]byte(fmt.Sprintf("%s/%d", seed, i)))
+		pk := hex.EncodeToString(h[:])
+		addr, err := tron.PrivateKeyHexToAddress(pk)
+		if err == nil {
+			return Key{PrivateKey: pk, Address: addr}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package trontest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// encodeRawData serializes raw_data the way java-tron does, as a protobuf
+// protocol.Transaction.raw, so raw_data_hex, the txID and signatures cover
+// the same bytes a real node would sign.
+func encodeRawData(rd rawDataJSON) ([]byte, error) {
+	refBytes, err := hex.DecodeString(rd.RefBlockBytes)
+	if err != nil {
+		return nil, fmt.Errorf("decode ref_block_bytes: %w", err)
+	}
+	refHash, err := hex.DecodeString(rd.RefBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("decode ref_block_hash: %w", err)
+	}
+
+	var b []byte
+	b = appendBytes(b, 1, refBytes)
+	b = appendBytes(b, 4, refHash)
+	b = appendVarint(b, 8, uint64(rd.Expiration))
+	for _, c := range rd.Contract {
+		contract, err := encodeContract(c)
+		if err != nil {
+			return nil, err
+		}
+		b = appendBytes(b, 11, contract)
+	}
+	b = appendVarint(b, 14, uint64(rd.Timestamp))
+	b = appendVarint(b, 18, uint64(rd.FeeLimit))
+	return b, nil
+}
+
+func encodeContract(c contractJSON) ([]byte, error) {
+	var typ tron.ContractType
+	var value []byte
+	switch c.Type {
+	case transferContractType:
+		var t transferJSON
+		if err := json.Unmarshal(c.Parameter.Value, &t); err != nil {
+			return nil, err
+		}
+		typ = tron.TransferContract
+		value = appendBytes(value, 1, t.OwnerAddress.Bytes())
+		value = appendBytes(value, 2, t.ToAddress.Bytes())
+		value = appendVarint(value, 3, uint64(t.Amount))
+
+	case transferAssetContractType:
+		var t transferAssetJSON
+		if err := json.Unmarshal(c.Parameter.Value, &t); err != nil {
+			return nil, err
+		}
+		typ = tron.TransferAssetContract
+		value = appendBytes(value, 1, []byte(t.AssetName))
+		value = appendBytes(value, 2, t.OwnerAddress.Bytes())
+		value = appendBytes(value, 3, t.ToAddress.Bytes())
+		value = appendVarint(value, 4, uint64(t.Amount))
+
+	case triggerSmartContractType:
+		var t triggerJSON
+		if err := json.Unmarshal(c.Parameter.Value, &t); err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(t.Data)
+		if err != nil {
+			return nil, fmt.Errorf("decode data: %w", err)
+		}
+		typ = tron.TriggerSmartContract
+		value = appendBytes(value, 1, t.OwnerAddress.Bytes())
+		value = appendBytes(value, 2, t.ContractAddress.Bytes())
+		value = appendBytes(value, 4, data)
+
+	default:
+		return nil, fmt.Errorf("unsupported contract type %s", c.Type)
+	}
+
+	var param []byte
+	param = protowire.AppendTag(param, 1, protowire.BytesType)
+	param = protowire.AppendString(param, c.Parameter.TypeURL)
+	param = appendBytes(param, 2, value)
+
+	var b []byte
+	b = appendVarint(b, 1, uint64(typ))
+	b = appendBytes(b, 2, param)
+	b = appendVarint(b, 5, uint64(c.PermissionID))
+	return b, nil
+}
+
+// appendVarint and appendBytes skip default values, as proto3 does.
+func appendVarint(b []byte, num protowire.Number, v uint64) []byte {
+	if v == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.VarintType)
+	return protowire.AppendVarint(b, v)
+}
+
+func appendBytes(b []byte, num protowire.Number, v []byte) []byte {
+	if len(v) == 0 {
+		return b
+	}
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendBytes(b, v)
+}
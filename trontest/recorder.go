@@ -0,0 +1,331 @@
+package trontest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sync"
+)
+
+type Mode int
+
+const (
+	// ModeAuto replays when the cassette exists and records otherwise.
+	ModeAuto Mode = iota
+	ModeReplay
+	ModeRecord
+)
+
+var (
+	// Fields that change between runs of the same flow. They are ignored at any
+	// depth of a JSON request body when matching.
+	defaultIgnoredFields = []string{
+		"timestamp",
+		"expiration",
+		"ref_block_bytes",
+		"ref_block_hash",
+		"raw_data_hex",
+		"txID",
+		"signature",
+	}
+
+	defaultScrubbedHeaders = []string{"TRON-PRO-API-KEY", "Authorization"}
+	defaultScrubbedParams  = []string{"apikey", "api_key"}
+)
+
+var ErrNoInteraction = errors.New("no recorded interaction")
+
+type RecorderOption func(*Recorder)
+
+func WithMode(mode Mode) RecorderOption {
+	return func(r *Recorder) { r.mode = mode }
+}
+
+// WithTransport sets the transport used while recording; it defaults to
+// http.DefaultTransport.
+func WithTransport(rt http.RoundTripper) RecorderOption {
+	return func(r *Recorder) { r.next = rt }
+}
+
+// WithIgnoredFields adds JSON body fields to ignore when matching requests.
+func WithIgnoredFields(fields ...string) RecorderOption {
+	return func(r *Recorder) { r.ignoredFields = append(r.ignoredFields, fields...) }
+}
+
+// WithScrubbedHeaders adds headers that are never written to the cassette.
+func WithScrubbedHeaders(headers ...string) RecorderOption {
+	return func(r *Recorder) { r.scrubbedHeaders = append(r.scrubbedHeaders, headers...) }
+}
+
+// recordedBody keeps JSON bodies as JSON for readable cassettes and anything
+// else as text.
+type recordedBody struct {
+	Body json.RawMessage `json:"body,omitempty"`
+	Text string          `json:"text,omitempty"`
+}
+
+func newRecordedBody(b []byte) recordedBody {
+	if len(b) > 0 && json.Valid(b) {
+		return recordedBody{Body: b}
+	}
+	return recordedBody{Text: string(b)}
+}
+
+func (b recordedBody) bytes() []byte {
+	if b.Body != nil {
+		return b.Body
+	}
+	return []byte(b.Text)
+}
+
+type recordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Header http.Header `json:"header,omitempty"`
+	recordedBody
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	recordedBody
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records node traffic to a cassette
+// file and replays it offline:
+//
+//	rec, err := trontest.NewRecorder("testdata/transfer.json")
+//	defer rec.Save()
+//	c := tron.New(nileURL, tron.WithHTTPClient(rec.HTTPClient()))
+//
+// Requests are matched on method, path and JSON body with volatile fields
+// removed. Identical requests replay their recorded responses in order, and
+// the last one repeats once they run out, so status polling replays cleanly.
+type Recorder struct {
+	path string
+	mode Mode
+	next http.RoundTripper
+
+	ignoredFields   []string
+	scrubbedHeaders []string
+
+	mu       sync.Mutex
+	recorded []interaction
+	replayed map[string]int
+}
+
+func NewRecorder(path string, opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		path:            path,
+		mode:            ModeAuto,
+		next:            http.DefaultTransport,
+		ignoredFields:   slices.Clone(defaultIgnoredFields),
+		scrubbedHeaders: slices.Clone(defaultScrubbedHeaders),
+		replayed:        make(map[string]int),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mode == ModeAuto {
+		r.mode = ModeReplay
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			r.mode = ModeRecord
+		}
+	}
+	if r.mode == ModeReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var c cassette
+		if err := json.Unmarshal(b, &c); err != nil {
+			return nil, fmt.Errorf("decode %s: %w", path, err)
+		}
+		r.recorded = c.Interactions
+	}
+	return r, nil
+}
+
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+func (r *Recorder) HTTPClient() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, body)
+	}
+	return r.record(req, body)
+}
+
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	key := r.matchKey(req.Method, r.scrubPath(req.URL), body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []int
+	for i, in := range r.recorded {
+		if r.matchKey(in.Request.Method, in.Request.Path, in.Request.bytes()) == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoInteraction, req.Method, req.URL.Path)
+	}
+
+	n := r.replayed[key]
+	r.replayed[key] = n + 1
+	in := r.recorded[matches[min(n, len(matches)-1)]]
+	respBody := in.Response.bytes()
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+		StatusCode:    in.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        in.Response.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) record(req *http.Request, body []byte) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	out.Body = io.NopCloser(bytes.NewReader(body))
+
+	resp, err := r.next.RoundTrip(out)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	in := interaction{
+		Request: recordedRequest{
+			Method:       req.Method,
+			Path:         r.scrubPath(req.URL),
+			Header:       r.scrubHeader(req.Header),
+			recordedBody: newRecordedBody(body),
+		},
+		Response: recordedResponse{
+			StatusCode:   resp.StatusCode,
+			Header:       r.scrubHeader(resp.Header),
+			recordedBody: newRecordedBody(respBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.recorded = append(r.recorded, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes the recorded interactions to the cassette. It does nothing in
+// replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	b, err := json.MarshalIndent(cassette{Interactions: r.recorded}, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, r.path)
+}
+
+func (r *Recorder) scrubHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for _, k := range r.scrubbedHeaders {
+		out.Del(k)
+	}
+	out.Del("Date")
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// scrubPath keeps the path and query but drops API key parameters, so the
+// cassette is independent of the node host and credentials.
+func (r *Recorder) scrubPath(u *url.URL) string {
+	q := u.Query()
+	for _, p := range defaultScrubbedParams {
+		q.Del(p)
+	}
+	if len(q) == 0 {
+		return u.Path
+	}
+	return u.Path + "?" + q.Encode()
+}
+
+func (r *Recorder) matchKey(method, path string, body []byte) string {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return method + " " + path + " " + string(body)
+	}
+	b, _ := json.Marshal(r.stripIgnored(v))
+	return method + " " + path + " " + string(b)
+}
+
+func (r *Recorder) stripIgnored(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if slices.Contains(r.ignoredFields, k) {
+				delete(t, k)
+				continue
+			}
+			t[k] = r.stripIgnored(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = r.stripIgnored(child)
+		}
+	}
+	return v
+}
@@ -0,0 +1,122 @@
+package trontest_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+	"github.com/snakoner/go-tron-lib/trontest"
+)
+
+func TestRecorderRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	node := trontest.NewNode()
+	alice := trontest.NewKey("alice")
+	node.Fund(alice.Address, 5_000_000)
+	url := node.URL()
+
+	rec, err := trontest.NewRecorder(cassette, trontest.WithMode(trontest.ModeRecord))
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	recorded, err := tron.New(url, tron.WithHTTPClient(rec.HTTPClient())).GetAccount(ctx, alice.Address)
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	node.Close()
+
+	rec, err = trontest.NewRecorder(cassette)
+	if err != nil {
+		t.Fatalf("open cassette: %v", err)
+	}
+	if rec.Mode() != trontest.ModeReplay {
+		t.Fatalf("mode = %v, want replay for an existing cassette", rec.Mode())
+	}
+	c := tron.New(url, tron.WithHTTPClient(rec.HTTPClient()), tron.WithRetry(0, 0))
+
+	replayed, err := c.GetAccount(ctx, alice.Address)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, replayed); err != nil {
+		t.Fatalf("compact: %v", err)
+	}
+	if compact.String() != string(recorded) {
+		t.Fatalf("replayed %s, recorded %s", compact.String(), recorded)
+	}
+
+	_, err = c.GetAccount(ctx, trontest.NewKey("bob").Address)
+	if !errors.Is(err, trontest.ErrNoInteraction) {
+		t.Fatalf("unrecorded request error = %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecorderScrubsAndIgnoresVolatileFields(t *testing.T) {
+	ctx := context.Background()
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+
+	node := trontest.NewNode()
+	alice, bob := trontest.NewKey("alice"), trontest.NewKey("bob")
+	node.Fund(alice.Address, 5_000_000)
+	url := node.URL()
+
+	rec, err := trontest.NewRecorder(cassette, trontest.WithMode(trontest.ModeRecord))
+	if err != nil {
+		t.Fatalf("new recorder: %v", err)
+	}
+	c := tron.New(url, tron.WithHTTPClient(rec.HTTPClient()), tron.WithTronGridAPIKey("secret-key"))
+	tx, err := c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	signed, err := tron.SignTransaction(tx, alice.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := c.BroadcastTransaction(ctx, signed); err != nil || !resp.Result {
+		t.Fatalf("broadcast = %+v, %v", resp, err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	node.Close()
+
+	data, err := os.ReadFile(cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte("secret-key")) {
+		t.Fatal("cassette contains the API key")
+	}
+
+	rec, err = trontest.NewRecorder(cassette)
+	if err != nil {
+		t.Fatalf("open cassette: %v", err)
+	}
+	c = tron.New(url, tron.WithHTTPClient(rec.HTTPClient()), tron.WithRetry(0, 0))
+
+	// A different signature still matches the recorded broadcast.
+	tx, err = c.BuildTransferTRXTx(ctx, alice.Address, bob.Address, big.NewInt(1_000_000))
+	if err != nil {
+		t.Fatalf("replay build: %v", err)
+	}
+	resigned, err := tron.SignTransaction(tx, bob.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp, err := c.BroadcastTransaction(ctx, resigned); err != nil || !resp.Result {
+		t.Fatalf("replay broadcast = %+v, %v", resp, err)
+	}
+}
@@ -0,0 +1,440 @@
+package tron
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	ChainIDMainnet = 0x2b6653dc
+	ChainIDNile    = 0xcd8690dc
+	ChainIDShasta  = 0x94a9059e
+)
+
+const typedDataDomainType = "EIP712Domain"
+
+var typedDataArrayRe = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type TypedDataDomain struct {
+	Name              string   `json:"name,omitempty"`
+	Version           string   `json:"version,omitempty"`
+	ChainID           *big.Int `json:"chainId,omitempty"`
+	VerifyingContract string   `json:"verifyingContract,omitempty"`
+	Salt              string   `json:"salt,omitempty"`
+}
+
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      TypedDataDomain             `json:"domain"`
+	Message     map[string]any              `json:"message"`
+}
+
+func (d TypedDataDomain) fields() ([]TypedDataField, map[string]any) {
+	var fields []TypedDataField
+	values := make(map[string]any)
+	if d.Name != "" {
+		fields = append(fields, TypedDataField{Name: "name", Type: "string"})
+		values["name"] = d.Name
+	}
+	if d.Version != "" {
+		fields = append(fields, TypedDataField{Name: "version", Type: "string"})
+		values["version"] = d.Version
+	}
+	if d.ChainID != nil {
+		// TRON's block.chainid is the low 4 bytes of the genesis block id.
+		fields = append(fields, TypedDataField{Name: "chainId", Type: "uint256"})
+		values["chainId"] = new(big.Int).And(d.ChainID, big.NewInt(0xffffffff))
+	}
+	if d.VerifyingContract != "" {
+		fields = append(fields, TypedDataField{Name: "verifyingContract", Type: "address"})
+		values["verifyingContract"] = d.VerifyingContract
+	}
+	if d.Salt != "" {
+		fields = append(fields, TypedDataField{Name: "salt", Type: "bytes32"})
+		values["salt"] = d.Salt
+	}
+	return fields, values
+}
+
+func (td *TypedData) types() map[string][]TypedDataField {
+	types := make(map[string][]TypedDataField, len(td.Types)+1)
+	for name, fields := range td.Types {
+		types[name] = fields
+	}
+	if _, ok := types[typedDataDomainType]; !ok {
+		types[typedDataDomainType], _ = td.Domain.fields()
+	}
+	return types
+}
+
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	_, values := td.Domain.fields()
+	return hashStruct(td.types(), typedDataDomainType, values)
+}
+
+// Hash returns the TIP-712 digest: keccak256(0x1901 || domainSeparator || hashStruct(message)).
+func (td *TypedData) Hash() ([]byte, error) {
+	if td.PrimaryType == "" {
+		return nil, errors.New("empty primaryType")
+	}
+
+	domainSeparator, err := td.DomainSeparator()
+	if err != nil {
+		return nil, fmt.Errorf("domain separator: %w", err)
+	}
+	messageHash, err := hashStruct(td.types(), td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, fmt.Errorf("hash message: %w", err)
+	}
+	return crypto.Keccak256([]byte{0x19, 0x01}, domainSeparator, messageHash), nil
+}
+
+// SignTypedData signs the typed data like TronWeb's _signTypedData and
+// returns the 0x-prefixed signature with a 27/28 recovery id.
+func SignTypedData(td *TypedData, privateKeyHex string) (string, error) {
+	priv, err := parsePrivateKeyHex(privateKeyHex)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := td.Hash()
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := crypto.Sign(hash, priv)
+	if err != nil {
+		return "", fmt.Errorf("sign failed: %w", err)
+	}
+	sig[64] += 27
+
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+func RecoverTypedDataSigner(td *TypedData, signature string) (string, error) {
+	hash, err := td.Hash()
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := recoverSigner(hash, signature)
+	if err != nil {
+		return "", err
+	}
+	return signer.String(), nil
+}
+
+func VerifyTypedData(td *TypedData, signature string, address string) (bool, error) {
+	want, err := ParseAddress(address)
+	if err != nil {
+		return false, fmt.Errorf("invalid address: %w", err)
+	}
+
+	hash, err := td.Hash()
+	if err != nil {
+		return false, err
+	}
+
+	got, err := recoverSigner(hash, signature)
+	if err != nil {
+		return false, err
+	}
+	return got == want, nil
+}
+
+func hashStruct(types map[string][]TypedDataField, primaryType string, data map[string]any) ([]byte, error) {
+	enc, err := encodeTypedData(types, primaryType, data)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Keccak256(enc), nil
+}
+
+func encodeTypedData(types map[string][]TypedDataField, primaryType string, data map[string]any) ([]byte, error) {
+	fields, ok := types[primaryType]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %q", primaryType)
+	}
+
+	typeString, err := encodeType(types, primaryType)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(crypto.Keccak256([]byte(typeString)))
+	for _, f := range fields {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing field %q", primaryType, f.Name)
+		}
+		word, err := encodeTypedValue(types, f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", primaryType, f.Name, err)
+		}
+		buf.Write(word)
+	}
+	return buf.Bytes(), nil
+}
+
+func encodeType(types map[string][]TypedDataField, primaryType string) (string, error) {
+	deps := make(map[string]bool)
+	if err := collectTypeDeps(types, primaryType, deps); err != nil {
+		return "", err
+	}
+	delete(deps, primaryType)
+
+	sorted := make([]string, 0, len(deps))
+	for d := range deps {
+		sorted = append(sorted, d)
+	}
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	for _, name := range append([]string{primaryType}, sorted...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type)
+			b.WriteByte(' ')
+			b.WriteString(f.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String(), nil
+}
+
+func collectTypeDeps(types map[string][]TypedDataField, typ string, deps map[string]bool) error {
+	typ = typedDataBaseType(typ)
+	if deps[typ] {
+		return nil
+	}
+	fields, ok := types[typ]
+	if !ok {
+		return nil
+	}
+	deps[typ] = true
+	for _, f := range fields {
+		if err := collectTypeDeps(types, f.Type, deps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func typedDataBaseType(typ string) string {
+	for {
+		m := typedDataArrayRe.FindStringSubmatch(typ)
+		if m == nil {
+			return typ
+		}
+		typ = m[1]
+	}
+}
+
+func encodeTypedValue(types map[string][]TypedDataField, typ string, v any) ([]byte, error) {
+	if m := typedDataArrayRe.FindStringSubmatch(typ); m != nil {
+		items, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected array for %s, got %T", typ, v)
+		}
+		if m[2] != "" {
+			n, _ := strconv.Atoi(m[2])
+			if len(items) != n {
+				return nil, fmt.Errorf("expected %d items for %s, got %d", n, typ, len(items))
+			}
+		}
+		var buf bytes.Buffer
+		for i, item := range items {
+			word, err := encodeTypedValue(types, m[1], item)
+			if err != nil {
+				return nil, fmt.Errorf("[%d]: %w", i, err)
+			}
+			buf.Write(word)
+		}
+		return crypto.Keccak256(buf.Bytes()), nil
+	}
+
+	if _, ok := types[typ]; ok {
+		data, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected object for %s, got %T", typ, v)
+		}
+		return hashStruct(types, typ, data)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string, got %T", v)
+		}
+		return crypto.Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, err := typedDataBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(b), nil
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool, got %T", v)
+		}
+		if b {
+			return common.LeftPadBytes([]byte{1}, 32), nil
+		}
+		return make([]byte, 32), nil
+	case typ == "address":
+		var addr Address
+		switch x := v.(type) {
+		case Address:
+			addr = x
+		case common.Address:
+			addr = AddressFromEVM(x)
+		case string:
+			parsed, err := ParseAddress(x)
+			if err != nil {
+				return nil, err
+			}
+			addr = parsed
+		default:
+			return nil, fmt.Errorf("expected address, got %T", v)
+		}
+		return common.LeftPadBytes(addr.EVM().Bytes(), 32), nil
+	case typ == "trcToken":
+		// TIP-712 encodes trcToken ids as uint256.
+		return encodeTypedInt("uint256", v)
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		return encodeTypedInt(typ, v)
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(strings.TrimPrefix(typ, "bytes"))
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("invalid type %s", typ)
+		}
+		b, err := typedDataBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > n {
+			return nil, fmt.Errorf("%s value has %d bytes", typ, len(b))
+		}
+		return common.RightPadBytes(b, 32), nil
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+func encodeTypedInt(typ string, v any) ([]byte, error) {
+	signed := strings.HasPrefix(typ, "int")
+	bits := 256
+	if s := strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 8 || n > 256 || n%8 != 0 {
+			return nil, fmt.Errorf("invalid type %s", typ)
+		}
+		bits = n
+	}
+
+	n, err := typedDataBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if !signed {
+		if n.Sign() < 0 || n.BitLen() > bits {
+			return nil, fmt.Errorf("%s out of range: %s", typ, n)
+		}
+		return common.LeftPadBytes(n.Bytes(), 32), nil
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+		return nil, fmt.Errorf("%s out of range: %s", typ, n)
+	}
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return common.LeftPadBytes(n.Bytes(), 32), nil
+}
+
+func typedDataBigInt(v any) (*big.Int, error) {
+	switch x := v.(type) {
+	case *big.Int:
+		if x == nil {
+			return nil, errors.New("nil integer")
+		}
+		return x, nil
+	case big.Int:
+		return &x, nil
+	case int:
+		return big.NewInt(int64(x)), nil
+	case int32:
+		return big.NewInt(int64(x)), nil
+	case int64:
+		return big.NewInt(x), nil
+	case uint:
+		return new(big.Int).SetUint64(uint64(x)), nil
+	case uint32:
+		return new(big.Int).SetUint64(uint64(x)), nil
+	case uint64:
+		return new(big.Int).SetUint64(x), nil
+	case float64:
+		f := new(big.Float).SetFloat64(x)
+		if !f.IsInt() {
+			return nil, fmt.Errorf("non-integer number %v", x)
+		}
+		n, _ := f.Int(nil)
+		return n, nil
+	case json.Number:
+		return typedDataBigInt(string(x))
+	case string:
+		s := strings.TrimSpace(x)
+		base := 10
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			s, base = s[2:], 16
+		}
+		n, ok := new(big.Int).SetString(s, base)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", x)
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("unsupported integer value %T", v)
+}
+
+func typedDataBytes(v any) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case common.Hash:
+		return x.Bytes(), nil
+	case string:
+		s := strings.TrimPrefix(strings.TrimPrefix(x, "0x"), "0X")
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hex bytes: %w", err)
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported bytes value %T", v)
+}
@@ -0,0 +1,96 @@
+package tron_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	tron "github.com/snakoner/go-tron-lib"
+)
+
+// mailTypedData is the EIP-712 reference example. With TRON addresses in their
+// EVM form TIP-712 hashes it exactly like EIP-712.
+func mailTypedData() *tron.TypedData {
+	return &tron.TypedData{
+		Types: map[string][]tron.TypedDataField{
+			"Person": {
+				{Name: "name", Type: "string"},
+				{Name: "wallet", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: tron.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainID:           big.NewInt(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: map[string]any{
+			"from": map[string]any{
+				"name":   "Cow",
+				"wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+			},
+			"to": map[string]any{
+				"name":   "Bob",
+				"wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+			},
+			"contents": "Hello, Bob!",
+		},
+	}
+}
+
+func TestTypedDataHash(t *testing.T) {
+	td := mailTypedData()
+
+	separator, err := td.DomainSeparator()
+	if err != nil {
+		t.Fatalf("domain separator: %v", err)
+	}
+	if got, want := hex.EncodeToString(separator), "f2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"; got != want {
+		t.Fatalf("domain separator = %s, want %s", got, want)
+	}
+
+	hash, err := td.Hash()
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	if got, want := hex.EncodeToString(hash), "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2"; got != want {
+		t.Fatalf("hash = %s, want %s", got, want)
+	}
+}
+
+func TestSignTypedData(t *testing.T) {
+	td := mailTypedData()
+
+	sig, err := tron.SignTypedData(td, cowKey)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	want := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	if sig != want {
+		t.Fatalf("signature = %s, want %s", sig, want)
+	}
+
+	signer, err := tron.RecoverTypedDataSigner(td, sig)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if signer != cowAddress {
+		t.Fatalf("signer = %s, want %s", signer, cowAddress)
+	}
+
+	td.Message["contents"] = "Hello, Alice!"
+	ok, err := tron.VerifyTypedData(td, sig, cowAddress)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if ok {
+		t.Fatal("signature verified for a different message")
+	}
+}
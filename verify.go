@@ -0,0 +1,237 @@
+package tron
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var contractTypeByName = map[string]ContractType{
+	"AccountCreateContract":           AccountCreateContract,
+	"TransferContract":                TransferContract,
+	"TransferAssetContract":           TransferAssetContract,
+	"VoteWitnessContract":             VoteWitnessContract,
+	"WitnessCreateContract":           WitnessCreateContract,
+	"AssetIssueContract":              AssetIssueContract,
+	"WitnessUpdateContract":           WitnessUpdateContract,
+	"ParticipateAssetIssueContract":   ParticipateAssetIssueContract,
+	"AccountUpdateContract":           AccountUpdateContract,
+	"FreezeBalanceContract":           FreezeBalanceContract,
+	"UnfreezeBalanceContract":         UnfreezeBalanceContract,
+	"WithdrawBalanceContract":         WithdrawBalanceContract,
+	"UnfreezeAssetContract":           UnfreezeAssetContract,
+	"UpdateAssetContract":             UpdateAssetContract,
+	"ProposalCreateContract":          ProposalCreateContract,
+	"ProposalApproveContract":         ProposalApproveContract,
+	"ProposalDeleteContract":          ProposalDeleteContract,
+	"SetAccountIdContract":            SetAccountIDContract,
+	"CreateSmartContract":             CreateSmartContract,
+	"TriggerSmartContract":            TriggerSmartContract,
+	"UpdateSettingContract":           UpdateSettingContract,
+	"ExchangeCreateContract":          ExchangeCreateContract,
+	"ExchangeInjectContract":          ExchangeInjectContract,
+	"ExchangeWithdrawContract":        ExchangeWithdrawContract,
+	"ExchangeTransactionContract":     ExchangeTransactionContract,
+	"UpdateEnergyLimitContract":       UpdateEnergyLimitContract,
+	"AccountPermissionUpdateContract": AccountPermissionUpdateContract,
+	"ClearABIContract":                ClearABIContract,
+	"UpdateBrokerageContract":         UpdateBrokerageContract,
+	"MarketSellAssetContract":         MarketSellAssetContract,
+	"MarketCancelOrderContract":       MarketCancelOrderContract,
+	"FreezeBalanceV2Contract":         FreezeBalanceV2Contract,
+	"UnfreezeBalanceV2Contract":       UnfreezeBalanceV2Contract,
+	"WithdrawExpireUnfreezeContract":  WithdrawExpireUnfreezeContract,
+	"DelegateResourceContract":        DelegateResourceContract,
+	"UnDelegateResourceContract":      UnDelegateResourceContract,
+	"CancelAllUnfreezeV2Contract":     CancelAllUnfreezeV2Contract,
+}
+
+func (t ContractType) String() string {
+	for name, ct := range contractTypeByName {
+		if ct == t {
+			return name
+		}
+	}
+	return fmt.Sprintf("ContractType(%d)", int(t))
+}
+
+// VerifyTransaction checks that txID matches raw_data_hex and recovers the
+// base58 address behind every signature, in signature order.
+func VerifyTransaction(signedTxJSON []byte) ([]string, error) {
+	signers, _, err := recoverTransactionSigners(signedTxJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]string, len(signers))
+	for i, s := range signers {
+		out[i] = s.String()
+	}
+	return out, nil
+}
+
+// recoverTransactionSigners also returns the signed raw_data bytes.
+func recoverTransactionSigners(signedTxJSON []byte) ([]Address, []byte, error) {
+	if len(signedTxJSON) == 0 {
+		return nil, nil, errors.New("empty tx json")
+	}
+
+	var tx TronTx
+	if err := json.Unmarshal(signedTxJSON, &tx); err != nil {
+		return nil, nil, fmt.Errorf("unmarshal tx: %w", err)
+	}
+	if tx.RawDataHex == "" {
+		return nil, nil, errors.New("missing raw_data_hex in tx")
+	}
+	if len(tx.Signature) == 0 {
+		return nil, nil, errors.New("tx has no signatures")
+	}
+
+	rawBytes, err := hex.DecodeString(strings.TrimPrefix(tx.RawDataHex, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode raw_data_hex: %w", err)
+	}
+
+	h := sha256.Sum256(rawBytes)
+	txidHex := hex.EncodeToString(h[:])
+	if tx.TxID != "" && !strings.EqualFold(tx.TxID, txidHex) {
+		return nil, nil, fmt.Errorf("txID mismatch: json=%s computed=%s", tx.TxID, txidHex)
+	}
+
+	signers := make([]Address, 0, len(tx.Signature))
+	for i, sigHex := range tx.Signature {
+		signer, err := recoverSigner(h[:], sigHex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("signature[%d]: %w", i, err)
+		}
+		signers = append(signers, signer)
+	}
+	return signers, rawBytes, nil
+}
+
+func recoverSigner(hash []byte, sigHex string) (Address, error) {
+	sigHex = strings.TrimPrefix(strings.TrimSpace(sigHex), "0x")
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return Address{}, fmt.Errorf("decode signature: %w", err)
+	}
+	if len(sig) != crypto.SignatureLength {
+		return Address{}, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+
+	// Some signers emit Ethereum-style recovery ids (27/28).
+	sig = append([]byte(nil), sig...)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	pub, err := crypto.SigToPub(hash, sig)
+	if err != nil {
+		return Address{}, fmt.Errorf("recover public key: %w", err)
+	}
+	return publicKeyToAddress(pub), nil
+}
+
+type PermissionCheck struct {
+	Owner        Address
+	PermissionID int
+	Signers      []Address
+	Unauthorized []Address
+	// Duplicates lists signers that signed more than once. java-tron rejects
+	// such transactions, so their weight is only counted once.
+	Duplicates    []Address
+	CurrentWeight int64
+	Threshold     int64
+}
+
+func (p *PermissionCheck) ThresholdMet() bool {
+	return len(p.Unauthorized) == 0 && len(p.Duplicates) == 0 && p.CurrentWeight >= p.Threshold
+}
+
+// VerifyTransactionPermissions recovers the signers and checks them against
+// the owner's on-chain permission referenced by the transaction.
+func (c *Client) VerifyTransactionPermissions(ctx context.Context, signedTxJSON []byte) (*PermissionCheck, error) {
+	signers, raw, err := recoverTransactionSigners(signedTxJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// Owner, permission and contract type come from the signed bytes, never
+	// from the unsigned raw_data JSON.
+	contracts, err := decodeRawContracts(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(contracts) != 1 {
+		return nil, fmt.Errorf("expected 1 contract in tx, got %d", len(contracts))
+	}
+	contract := contracts[0]
+
+	owner := contract.Owner
+	if owner.IsZero() {
+		return nil, errors.New("missing owner_address in tx")
+	}
+
+	perms, err := c.GetAccountPermissions(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	if perms.Owner == nil {
+		// Accounts that never updated permissions are controlled by their own key.
+		perms.Owner = &Permission{
+			Threshold: 1,
+			Keys:      []PermissionKey{{Address: owner, Weight: 1}},
+		}
+	}
+
+	perm, err := perms.Permission(contract.PermissionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if contract.PermissionID != OwnerPermissionID {
+		allowed, err := PermissionAllows(perm.Operations, contract.Type)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return nil, fmt.Errorf("permission %d does not allow %s", contract.PermissionID, contract.Type)
+		}
+	}
+
+	weights := make(map[Address]int64, len(perm.Keys))
+	for _, k := range perm.Keys {
+		weights[k.Address] = k.Weight
+	}
+
+	check := &PermissionCheck{
+		Owner:        owner,
+		PermissionID: contract.PermissionID,
+		Signers:      signers,
+		Threshold:    perm.Threshold,
+	}
+	seen := make(map[Address]int, len(signers))
+	for _, s := range signers {
+		seen[s]++
+		if seen[s] > 1 {
+			if seen[s] == 2 {
+				check.Duplicates = append(check.Duplicates, s)
+			}
+			continue
+		}
+
+		w, ok := weights[s]
+		if !ok {
+			check.Unauthorized = append(check.Unauthorized, s)
+			continue
+		}
+		check.CurrentWeight += w
+	}
+	return check, nil
+}